@@ -0,0 +1,43 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+)
+
+// requireAdminKey only lets requests through that present the configured ADMIN_API_KEY in
+// the X-Admin-Key header. Admin endpoints are disabled entirely when no key is configured.
+func (s *LocationService) requireAdminKey(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.config.AdminAPIKey == "" {
+			http.NotFound(w, r)
+			return
+		}
+		given := r.Header.Get("X-Admin-Key")
+		if subtle.ConstantTimeCompare([]byte(given), []byte(s.config.AdminAPIKey)) != 1 {
+			writeError(w, http.StatusUnauthorized, ErrCodeUnauthorized, "Missing or invalid X-Admin-Key")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// handleCacheStats reports hit/miss/eviction counters, entry counts and approximate memory
+// for each cache. Pass ?reset=true to zero the counters after reading them.
+func (s *LocationService) handleCacheStats(w http.ResponseWriter, r *http.Request) {
+	stats := map[string]CacheStats{
+		"results": s.resultCache.Stats(),
+		"geocode": s.geocodeCache.Stats(),
+		"photos":  s.photoCache.Stats(),
+	}
+
+	if r.URL.Query().Get("reset") == "true" {
+		s.resultCache.ResetStats()
+		s.geocodeCache.ResetStats()
+		s.photoCache.ResetStats()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stats)
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"sync"
+
+	"googlemaps.github.io/maps"
+)
+
+// APICallCount reports the live Maps API calls made while serving one request
+type APICallCount struct {
+	Total int `json:"total"`
+	// ByType counts calls per API: geocode, reverse_geocode, nearby, details, matrix, roads, photo
+	ByType map[string]int `json:"by_type"`
+}
+
+// apiCallCounterKey is the context key for a request's apiCallCounter
+type apiCallCounterKey struct{}
+
+// apiCallCounter tallies Maps calls per API; calls can run concurrently, hence the lock
+type apiCallCounter struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+// withAPICallCounter returns a context under which every Maps call is counted in counter
+func withAPICallCounter(ctx context.Context) (_ context.Context, counter *apiCallCounter) {
+	counter = &apiCallCounter{counts: make(map[string]int)}
+	return context.WithValue(ctx, apiCallCounterKey{}, counter), counter
+}
+
+// countAPICall records one call of the given type against the context's counter, if any
+func countAPICall(ctx context.Context, callType string) {
+	counter, ok := ctx.Value(apiCallCounterKey{}).(*apiCallCounter)
+	if !ok {
+		return
+	}
+	counter.mu.Lock()
+	counter.counts[callType]++
+	counter.mu.Unlock()
+}
+
+// Snapshot returns the counts so far. Requests answered entirely from cache report zero.
+func (c *apiCallCounter) Snapshot() *APICallCount {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	count := &APICallCount{ByType: make(map[string]int, len(c.counts))}
+	for callType, n := range c.counts {
+		count.ByType[callType] = n
+		count.Total += n
+	}
+	return count
+}
+
+// countingClient wraps a MapsClient so every call is counted against the request context.
+// Each attempt counts, including retries and the extra pages of a paginated search, since
+// each is billed.
+type countingClient struct {
+	MapsClient
+}
+
+func (c countingClient) Geocode(ctx context.Context, r *maps.GeocodingRequest) ([]maps.GeocodingResult, error) {
+	countAPICall(ctx, "geocode")
+	return c.MapsClient.Geocode(ctx, r)
+}
+
+func (c countingClient) ReverseGeocode(ctx context.Context, r *maps.GeocodingRequest) ([]maps.GeocodingResult, error) {
+	countAPICall(ctx, "reverse_geocode")
+	return c.MapsClient.ReverseGeocode(ctx, r)
+}
+
+func (c countingClient) NearbySearch(ctx context.Context, r *maps.NearbySearchRequest) (maps.PlacesSearchResponse, error) {
+	countAPICall(ctx, "nearby")
+	return c.MapsClient.NearbySearch(ctx, r)
+}
+
+func (c countingClient) PlaceDetails(ctx context.Context, r *maps.PlaceDetailsRequest) (maps.PlaceDetailsResult, error) {
+	countAPICall(ctx, "details")
+	return c.MapsClient.PlaceDetails(ctx, r)
+}
+
+func (c countingClient) DistanceMatrix(ctx context.Context, r *maps.DistanceMatrixRequest) (*maps.DistanceMatrixResponse, error) {
+	countAPICall(ctx, "matrix")
+	return c.MapsClient.DistanceMatrix(ctx, r)
+}
+
+func (c countingClient) NearestRoads(ctx context.Context, r *maps.NearestRoadsRequest) (*maps.NearestRoadsResponse, error) {
+	countAPICall(ctx, "roads")
+	return c.MapsClient.NearestRoads(ctx, r)
+}
+
+func (c countingClient) PlacePhoto(ctx context.Context, r *maps.PlacePhotoRequest) (maps.PlacePhotoResponse, error) {
+	countAPICall(ctx, "photo")
+	return c.MapsClient.PlacePhoto(ctx, r)
+}
+
+// apiCallContext attaches a call counter to ctx when Config.Debug is on and the client
+// opted in with the X-Include-API-Calls header; counter is nil otherwise. Call counts reveal
+// how much a request costs and what the caches hold, so they are not for public clients.
+func (s *LocationService) apiCallContext(ctx context.Context, header string) (context.Context, *apiCallCounter) {
+	if !s.config.Debug || header != "true" {
+		return ctx, nil
+	}
+	return withAPICallCounter(ctx)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"googlemaps.github.io/maps"
+)
+
+func TestAPICallCountsNeedDebug(t *testing.T) {
+	tests := []struct {
+		name   string
+		debug  bool
+		header string
+		want   *APICallCount
+	}{
+		{"debug with header", true, "true", &APICallCount{Total: 1, ByType: map[string]int{"geocode": 1}}},
+		{"debug without header", true, "", nil},
+		{"header without debug", false, "true", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeMapsClient{geocodes: map[string][]maps.GeocodingResult{
+				"110001": fakePostalGeocode("110001", "New Delhi", "India"),
+			}}
+			service := newTestService(t, client)
+			service.config.Debug = tt.debug
+
+			r := httptest.NewRequest(http.MethodPost, "/api/validate-pincode", strings.NewReader(`{"pin_code": "110001", "city": "New Delhi"}`))
+			r.Header.Set("X-Include-API-Calls", tt.header)
+			w := httptest.NewRecorder()
+			service.handleValidatePinCode(w, r)
+
+			var response ValidationResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("body %q is not JSON: %v", w.Body.String(), err)
+			}
+			if !response.Valid {
+				t.Fatalf("validation failed: %s", response.Message)
+			}
+			if !reflect.DeepEqual(response.APICalls, tt.want) {
+				t.Errorf("api_calls = %+v, want %+v", response.APICalls, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"googlemaps.github.io/maps"
+)
+
+// Google Maps Platform APIs the service depends on
+const (
+	geocodingAPI = "Geocoding API"
+	placesAPI    = "Places API"
+)
+
+// ErrCodeAPINotEnabled is returned to clients when the API key can't use a required Maps API
+const ErrCodeAPINotEnabled = "API_NOT_ENABLED"
+
+// APINotEnabledError reports that the configured API key is denied access to a Maps API,
+// typically because that API isn't enabled in the Google Cloud project.
+type APINotEnabledError struct {
+	API string
+	Err error
+}
+
+func (e *APINotEnabledError) Error() string {
+	return fmt.Sprintf("%s is not enabled for this API key: %v", e.API, e.Err)
+}
+
+func (e *APINotEnabledError) Unwrap() error {
+	return e.Err
+}
+
+// checkAPIEnabled wraps err in an APINotEnabledError when the Maps client reports
+// REQUEST_DENIED because the API isn't enabled; other errors are returned unchanged.
+func checkAPIEnabled(api string, err error) error {
+	if err == nil {
+		return nil
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, "REQUEST_DENIED") {
+		return err
+	}
+	lower := strings.ToLower(msg)
+	for _, hint := range []string{"not authorized to use this api", "not activated", "not enabled", "disabled"} {
+		if strings.Contains(lower, hint) {
+			return &APINotEnabledError{API: api, Err: err}
+		}
+	}
+	return err
+}
+
+// isAPINotEnabled reports whether err was caused by a required Maps API being disabled
+func isAPINotEnabled(err error) bool {
+	var notEnabled *APINotEnabledError
+	return errors.As(err, &notEnabled)
+}
+
+// checkRequiredAPIs makes one cheap call to each required Maps API and returns the
+// first APINotEnabledError found. Other errors (network, quota) are not reported here.
+func (s *LocationService) checkRequiredAPIs(ctx context.Context) error {
+	_, err := s.mapsClient.Geocode(ctx, &maps.GeocodingRequest{Address: "110001"})
+	if err := checkAPIEnabled(geocodingAPI, err); isAPINotEnabled(err) {
+		return err
+	}
+
+	_, err = s.mapsClient.NearbySearch(ctx, &maps.NearbySearchRequest{
+		Location: &maps.LatLng{Lat: 28.6139, Lng: 77.2090},
+		Radius:   100,
+	})
+	if err := checkAPIEnabled(placesAPI, err); isAPINotEnabled(err) {
+		return err
+	}
+
+	return nil
+}
+
+// warnOnMissingAPIs logs a startup warning when the API key lacks a required Maps API
+func (s *LocationService) warnOnMissingAPIs() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := s.checkRequiredAPIs(ctx); err != nil {
+		log.Printf("WARNING: %v. Enable the %s and %s for this key in the Google Cloud console.", err, geocodingAPI, placesAPI)
+	}
+}
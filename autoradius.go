@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// autoRadiusCandidates are the radii in meters probed when AutoRadius is set, smallest first.
+// Each costs one nearby search, so the list doubles as the probe bound.
+var autoRadiusCandidates = []float64{500, 1000, 2000}
+
+// defaultAutoRadiusMinResults is how many landmarks a radius must yield to be chosen
+const defaultAutoRadiusMinResults = 3
+
+// probeRadii searches every candidate radius concurrently and returns the results of the
+// smallest one yielding enough landmarks, or of the largest radius if none does
+func (s *LocationService) probeRadii(ctx context.Context, center *searchCenter, placeTypes []string, req GetLandmarksRequest) (*scoredSet, error) {
+	minResults := req.AutoRadiusMinResults
+	if minResults <= 0 {
+		minResults = defaultAutoRadiusMinResults
+	}
+
+	sets := make([]*scoredSet, len(autoRadiusCandidates))
+	errs := make([]error, len(autoRadiusCandidates))
+
+	var wg sync.WaitGroup
+	for i, radius := range autoRadiusCandidates {
+		wg.Add(1)
+		go func(i int, radius float64) {
+			defer wg.Done()
+			sets[i], errs[i] = s.nearbyScored(ctx, center, radius, placeTypes, req)
+		}(i, radius)
+	}
+	wg.Wait()
+
+	var largest *scoredSet
+	for i, set := range sets {
+		if errs[i] != nil {
+			continue
+		}
+		if len(set.Landmarks) >= minResults {
+			return set, nil
+		}
+		largest = set
+	}
+	if largest == nil {
+		return nil, errs[0]
+	}
+	return largest, nil
+}
+
+// minExpandTimeLeft is the least time that must remain before the request deadline to try
+// a larger radius; a search can take several seconds when it pages through results
+const minExpandTimeLeft = 3 * time.Second
+
+// expandRadius searches at radius and, while fewer than req.MinResults landmarks are found,
+// doubles the radius up to Config.MaxSearchRadius. When time runs short or a larger search
+// fails, it settles for the last successful result rather than failing the request.
+func (s *LocationService) expandRadius(ctx context.Context, center *searchCenter, radius float64, placeTypes []string, req GetLandmarksRequest) (*scoredSet, error) {
+	set, err := s.nearbyScored(ctx, center, radius, placeTypes, req)
+	if err != nil {
+		return nil, err
+	}
+
+	for len(set.Landmarks) < req.MinResults && radius < s.config.MaxSearchRadius {
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < minExpandTimeLeft {
+			break
+		}
+		radius = min(radius*2, s.config.MaxSearchRadius)
+		larger, err := s.nearbyScored(ctx, center, radius, placeTypes, req)
+		if err != nil {
+			log.Printf("Expanding search to %.0fm failed, keeping %.0fm results: %v", radius, set.RadiusUsed, err)
+			break
+		}
+		set = larger
+	}
+	return set, nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// maxBatchSize caps the number of locations in one batch request
+const maxBatchSize = 20
+
+// batchConcurrency is how many locations of a batch are searched at once
+const batchConcurrency = 4
+
+type BatchLandmarksRequest struct {
+	Requests []GetLandmarksRequest `json:"requests"`
+}
+
+// BatchLandmarksItem is one line of a streamed batch response. Index is the position of
+// the location in the request; items arrive in completion order, not input order.
+type BatchLandmarksItem struct {
+	Index    int                `json:"index"`
+	Response *LandmarksResponse `json:"response,omitempty"`
+	Error    string             `json:"error,omitempty"`
+}
+
+// handleStreamBatchLandmarks searches several locations with a bounded worker pool and
+// writes each result as a line of NDJSON as soon as it finishes. If the client
+// disconnects, searches not yet started are skipped and running ones are cancelled.
+func (s *LocationService) handleStreamBatchLandmarks(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, ErrCodeInternal, "Streaming not supported")
+		return
+	}
+
+	var req BatchLandmarksRequest
+	if err := s.decodeJSON(r.Body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid request body")
+		return
+	}
+	if len(req.Requests) == 0 || len(req.Requests) > maxBatchSize {
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidRequest,
+			fmt.Sprintf("A batch must contain between 1 and %d requests", maxBatchSize))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	results := make(chan BatchLandmarksItem)
+	sem := make(chan struct{}, batchConcurrency)
+	var wg sync.WaitGroup
+	for i, item := range req.Requests {
+		wg.Add(1)
+		go func(i int, item GetLandmarksRequest) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return
+			}
+
+			itemCtx, itemCancel := context.WithTimeout(ctx, 10*time.Second)
+			defer itemCancel()
+
+			result := BatchLandmarksItem{Index: i}
+			response, err := s.GetNearbyLandmarks(itemCtx, item)
+			if err != nil {
+				result.Error = err.Error()
+			} else {
+				result.Response = response
+			}
+
+			select {
+			case results <- result:
+			case <-ctx.Done():
+			}
+		}(i, item)
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	encoder := json.NewEncoder(w)
+	for result := range results {
+		if err := encoder.Encode(result); err != nil {
+			cancel()
+			continue
+		}
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCalculateBearing(t *testing.T) {
+	const lat, lng = 26.4499, 80.3319
+	tests := []struct {
+		name       string
+		lat2, lng2 float64
+		want       float64
+	}{
+		{"north", lat + 0.01, lng, 0},
+		{"east", lat, lng + 0.01, 90},
+		{"south", lat - 0.01, lng, 180},
+		{"west", lat, lng - 0.01, 270},
+		{"northeast", lat + 0.01, lng + 0.01/math.Cos(lat*math.Pi/180), 45},
+		{"southwest", lat - 0.01, lng - 0.01/math.Cos(lat*math.Pi/180), 225},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := calculateBearing(lat, lng, tt.lat2, tt.lng2)
+			// East and west drift slightly north of 90/270 along a great circle
+			if math.Abs(got-tt.want) > 0.1 {
+				t.Errorf("bearing = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompassDirection(t *testing.T) {
+	tests := []struct {
+		bearing float64
+		want    string
+	}{
+		{0, "N"},
+		{22.4999, "N"},
+		{22.5, "NE"},
+		{45, "NE"},
+		{67.4999, "NE"},
+		{67.5, "E"},
+		{90, "E"},
+		{112.5, "SE"},
+		{135, "SE"},
+		{157.5, "S"},
+		{180, "S"},
+		{202.5, "SW"},
+		{225, "SW"},
+		{247.5, "W"},
+		{270, "W"},
+		{292.5, "NW"},
+		{315, "NW"},
+		{337.4999, "NW"},
+		{337.5, "N"},
+		{359.9999, "N"},
+		{360, "N"},
+		{-22.5, "N"},
+		{-22.5001, "NW"},
+	}
+	for _, tt := range tests {
+		if got := compassDirection(tt.bearing); got != tt.want {
+			t.Errorf("compassDirection(%v) = %s, want %s", tt.bearing, got, tt.want)
+		}
+	}
+}
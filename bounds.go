@@ -0,0 +1,39 @@
+package main
+
+import "math"
+
+// BoundingCircle is a circle enclosing the search center and the returned landmarks
+type BoundingCircle struct {
+	Center Location `json:"center"`
+	Radius float64  `json:"radius"` // meters
+}
+
+// boundingCircle approximates the smallest circle enclosing origin and the landmarks: it
+// is centered on their centroid with a radius reaching the farthest point. The result is
+// at most about twice the true minimum, which is close enough for picking a map zoom.
+// Returns nil when there are no landmarks.
+func boundingCircle(origin Location, landmarks []Landmark) *BoundingCircle {
+	if len(landmarks) == 0 {
+		return nil
+	}
+
+	points := make([]Location, 0, len(landmarks)+1)
+	points = append(points, origin)
+	for _, landmark := range landmarks {
+		points = append(points, landmark.Location)
+	}
+
+	var center Location
+	for _, point := range points {
+		center.Lat += point.Lat
+		center.Lng += point.Lng
+	}
+	center.Lat /= float64(len(points))
+	center.Lng /= float64(len(points))
+
+	radius := 0.0
+	for _, point := range points {
+		radius = math.Max(radius, calculateDistance(center.Lat, center.Lng, point.Lat, point.Lng))
+	}
+	return &BoundingCircle{Center: center, Radius: math.Ceil(radius)}
+}
@@ -0,0 +1,15 @@
+package main
+
+import "sort"
+
+// sortQuietFirst orders landmarks by busyness, quietest first. Landmarks without a
+// busyness value keep their relative order after those with one.
+func sortQuietFirst(landmarks []Landmark) {
+	sort.SliceStable(landmarks, func(i, j int) bool {
+		a, b := landmarks[i].Busyness, landmarks[j].Busyness
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return *a < *b
+	})
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"container/list"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ttlCache is a concurrency-safe in-memory cache whose entries expire after a fixed TTL.
+// Expired entries are kept for a further staleFor period so callers can fall back to them
+// when a refresh fails, and are evicted lazily when read after that. With maxEntries set,
+// the least recently used entry is evicted whenever a new one would exceed the bound.
+type ttlCache[V any] struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	staleFor   time.Duration
+	maxEntries int
+	entries    map[string]*list.Element
+	// order holds *cacheEntry values, most recently used at the front
+	order *list.List
+
+	// sizer estimates an entry's memory footprint in bytes for stats; optional
+	sizer func(V) int
+	bytes int
+	// ttlFactor scales the TTL at read time, e.g. to keep entries fresh longer during
+	// quiet hours; optional, defaults to 1
+	ttlFactor func() float64
+
+	hits         atomic.Int64
+	misses       atomic.Int64
+	evictions    atomic.Int64
+	lruEvictions atomic.Int64
+}
+
+type cacheEntry[V any] struct {
+	key      string
+	value    V
+	storedAt time.Time
+	size     int
+}
+
+// CacheStats is a point-in-time snapshot of a cache's counters
+type CacheStats struct {
+	Entries   int   `json:"entries"`
+	Hits      int64 `json:"hits"`
+	Misses    int64 `json:"misses"`
+	Evictions int64 `json:"evictions"`
+	// LRUEvictions counts the evictions made to stay within MaxEntries, a subset of Evictions
+	LRUEvictions int64 `json:"lru_evictions"`
+	// MaxEntries is the entry bound, 0 when unbounded
+	MaxEntries  int `json:"max_entries"`
+	ApproxBytes int `json:"approx_bytes"`
+}
+
+// newTTLCache creates an empty cache with the given entry lifetime, no stale window and
+// at most maxEntries entries (0 for no bound)
+func newTTLCache[V any](ttl time.Duration, maxEntries int) *ttlCache[V] {
+	return newStaleTTLCache[V](ttl, 0, maxEntries)
+}
+
+// newStaleTTLCache creates an empty cache whose expired entries remain readable via
+// GetStale for staleFor after they expire, holding at most maxEntries (0 for no bound)
+func newStaleTTLCache[V any](ttl, staleFor time.Duration, maxEntries int) *ttlCache[V] {
+	return &ttlCache[V]{
+		ttl:        ttl,
+		staleFor:   staleFor,
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// Get returns the cached value for key if present and not expired
+func (c *ttlCache[V]) Get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.lookup(key)
+	if !ok || time.Now().After(entry.storedAt.Add(c.freshFor())) {
+		c.misses.Add(1)
+		var zero V
+		return zero, false
+	}
+	c.hits.Add(1)
+	return entry.value, true
+}
+
+// GetStale returns the cached value for key even if it has expired, as long as it is
+// still within the stale window
+func (c *ttlCache[V]) GetStale(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.lookup(key)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return entry.value, true
+}
+
+// lookup returns the entry for key and marks it most recently used, evicting it instead
+// if it is past the stale window. The caller must hold c.mu.
+func (c *ttlCache[V]) lookup(key string) (*cacheEntry[V], bool) {
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*cacheEntry[V])
+	if time.Now().After(entry.storedAt.Add(max(c.freshFor(), c.ttl+c.staleFor))) {
+		c.remove(element)
+		c.evictions.Add(1)
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return entry, true
+}
+
+// freshFor is how long entries currently count as fresh: the TTL scaled by ttlFactor
+func (c *ttlCache[V]) freshFor() time.Duration {
+	if c.ttlFactor == nil {
+		return c.ttl
+	}
+	return time.Duration(float64(c.ttl) * c.ttlFactor())
+}
+
+// remove deletes an entry and its size accounting. The caller must hold c.mu.
+func (c *ttlCache[V]) remove(element *list.Element) {
+	entry := c.order.Remove(element).(*cacheEntry[V])
+	delete(c.entries, entry.key)
+	c.bytes -= entry.size
+}
+
+// Set stores value under key for the cache's TTL, evicting the least recently used entry
+// if the cache is full
+func (c *ttlCache[V]) Set(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if old, ok := c.entries[key]; ok {
+		c.remove(old)
+	}
+
+	entry := &cacheEntry[V]{
+		key:      key,
+		value:    value,
+		storedAt: time.Now(),
+	}
+	if c.sizer != nil {
+		entry.size = len(key) + c.sizer(value)
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	c.bytes += entry.size
+
+	for c.maxEntries > 0 && len(c.entries) > c.maxEntries {
+		c.remove(c.order.Back())
+		c.evictions.Add(1)
+		c.lruEvictions.Add(1)
+	}
+}
+
+// Stats returns the cache's current counters
+func (c *ttlCache[V]) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return CacheStats{
+		Entries:      len(c.entries),
+		Hits:         c.hits.Load(),
+		Misses:       c.misses.Load(),
+		Evictions:    c.evictions.Load(),
+		LRUEvictions: c.lruEvictions.Load(),
+		MaxEntries:   c.maxEntries,
+		ApproxBytes:  c.bytes,
+	}
+}
+
+// ResetStats zeroes the hit, miss and eviction counters without touching entries
+func (c *ttlCache[V]) ResetStats() {
+	c.hits.Store(0)
+	c.misses.Store(0)
+	c.evictions.Store(0)
+	c.lruEvictions.Store(0)
+}
+
+// jsonSize approximates a value's memory footprint by its JSON encoding length
+func jsonSize[V any](value V) int {
+	data, _ := json.Marshal(value)
+	return len(data)
+}
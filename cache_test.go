@@ -0,0 +1,61 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTTLCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newTTLCache[int](time.Hour, 2)
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	// Reading a makes b the least recently used entry
+	if got, ok := cache.Get("a"); !ok || got != 1 {
+		t.Fatalf("Get(a) = %d, %v, want 1, true", got, ok)
+	}
+	cache.Set("c", 3)
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("b is still cached, want it evicted as least recently used")
+	}
+	for key, want := range map[string]int{"a": 1, "c": 3} {
+		if got, ok := cache.Get(key); !ok || got != want {
+			t.Errorf("Get(%s) = %d, %v, want %d, true", key, got, ok, want)
+		}
+	}
+
+	stats := cache.Stats()
+	if stats.Entries != 2 || stats.MaxEntries != 2 {
+		t.Errorf("entries = %d of %d, want 2 of 2", stats.Entries, stats.MaxEntries)
+	}
+	if stats.LRUEvictions != 1 || stats.Evictions != 1 {
+		t.Errorf("lru_evictions = %d, evictions = %d, want 1 and 1", stats.LRUEvictions, stats.Evictions)
+	}
+}
+
+func TestTTLCacheReplacingKeyDoesNotEvict(t *testing.T) {
+	cache := newTTLCache[int](time.Hour, 2)
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	cache.Set("a", 10)
+
+	if got, _ := cache.Get("a"); got != 10 {
+		t.Errorf("Get(a) = %d, want the new value 10", got)
+	}
+	if _, ok := cache.Get("b"); !ok {
+		t.Error("b was evicted, want replacing a key to keep the entry count")
+	}
+	if stats := cache.Stats(); stats.Entries != 2 || stats.LRUEvictions != 0 {
+		t.Errorf("entries = %d, lru_evictions = %d, want 2 and 0", stats.Entries, stats.LRUEvictions)
+	}
+}
+
+func TestTTLCacheUnbounded(t *testing.T) {
+	cache := newTTLCache[int](time.Hour, 0)
+	for i := 0; i < 100; i++ {
+		cache.Set(string(rune('A'+i)), i)
+	}
+	if stats := cache.Stats(); stats.Entries != 100 || stats.LRUEvictions != 0 {
+		t.Errorf("entries = %d, lru_evictions = %d, want 100 and 0", stats.Entries, stats.LRUEvictions)
+	}
+}
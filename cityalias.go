@@ -0,0 +1,82 @@
+package main
+
+import (
+	"log"
+	"strings"
+)
+
+// defaultCityAliases maps former or alternate Indian city names to their current official names.
+// Extend or override it with the CITY_ALIASES env var.
+var defaultCityAliases = map[string]string{
+	"bangalore":   "bengaluru",
+	"bombay":      "mumbai",
+	"calcutta":    "kolkata",
+	"madras":      "chennai",
+	"poona":       "pune",
+	"gurgaon":     "gurugram",
+	"trivandrum":  "thiruvananthapuram",
+	"cochin":      "kochi",
+	"calicut":     "kozhikode",
+	"mysore":      "mysuru",
+	"mangalore":   "mangaluru",
+	"belgaum":     "belagavi",
+	"hubli":       "hubballi",
+	"baroda":      "vadodara",
+	"benares":     "varanasi",
+	"banaras":     "varanasi",
+	"allahabad":   "prayagraj",
+	"pondicherry": "puducherry",
+	"simla":       "shimla",
+	"cawnpore":    "kanpur",
+	"vizag":       "visakhapatnam",
+}
+
+// parseCityAliases merges "old=new" pairs from a comma-separated list over the defaults
+func parseCityAliases(value string) map[string]string {
+	aliases := make(map[string]string, len(defaultCityAliases))
+	for alias, canonical := range defaultCityAliases {
+		aliases[alias] = canonical
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		alias, canonical, ok := strings.Cut(pair, "=")
+		alias = strings.ToLower(strings.TrimSpace(alias))
+		canonical = strings.ToLower(strings.TrimSpace(canonical))
+		if !ok || alias == "" || canonical == "" {
+			log.Printf("Ignoring invalid CITY_ALIASES entry %q", pair)
+			continue
+		}
+		aliases[alias] = canonical
+	}
+	return aliases
+}
+
+// canonicalCity rewrites a lowercase city name using the alias table. The whole name is
+// looked up first, then each word, so "bangalore urban" becomes "bengaluru urban".
+func (s *LocationService) canonicalCity(city string) string {
+	if canonical, ok := s.config.CityAliases[city]; ok {
+		return canonical
+	}
+
+	words := strings.Fields(city)
+	for i, word := range words {
+		if canonical, ok := s.config.CityAliases[word]; ok {
+			words[i] = canonical
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// citiesMatch reports whether two lowercase city names refer to the same city.
+// Plain substring matching is tried first; alias resolution only runs when it fails.
+func (s *LocationService) citiesMatch(found, given string) bool {
+	if strings.Contains(found, given) || strings.Contains(given, found) {
+		return true
+	}
+
+	found, given = s.canonicalCity(found), s.canonicalCity(given)
+	return strings.Contains(found, given) || strings.Contains(given, found)
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strings"
+)
+
+// minGzipSize is the smallest response body worth compressing; smaller bodies, like
+// /health, are sent as is
+const minGzipSize = 1024
+
+// gzipMiddleware compresses responses for clients that send Accept-Encoding: gzip. The
+// first minGzipSize bytes are buffered to decide whether compressing is worthwhile.
+// Streaming handlers keep working: a Flush compresses what is buffered and flushes it.
+func gzipMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		if !acceptsGzip(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		gw := &gzipResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(gw, r)
+		// Not deferred: after a panic nothing buffered is sent, so recoverMiddleware can
+		// still answer with a clean 500
+		gw.finish()
+	})
+}
+
+// acceptsGzip reports whether the request's Accept-Encoding lists gzip
+func acceptsGzip(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		encoding, _, _ := strings.Cut(part, ";")
+		if strings.TrimSpace(encoding) == "gzip" {
+			return true
+		}
+	}
+	return false
+}
+
+// gzipResponseWriter buffers the start of a response, then either compresses it or passes
+// it through unchanged
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	status      int
+	buf         []byte
+	gz          *gzip.Writer
+	passthrough bool
+}
+
+func (w *gzipResponseWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *gzipResponseWriter) Write(p []byte) (int, error) {
+	switch {
+	case w.passthrough:
+		return w.ResponseWriter.Write(p)
+	case w.gz != nil:
+		return w.gz.Write(p)
+	}
+	w.buf = append(w.buf, p...)
+	if len(w.buf) >= minGzipSize {
+		if err := w.start(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush sends buffered output, compressed, so streaming responses reach the client promptly
+func (w *gzipResponseWriter) Flush() {
+	if w.gz == nil && !w.passthrough {
+		w.start(true)
+	}
+	if w.gz != nil {
+		w.gz.Flush()
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// start sends the headers and buffered bytes, compressed if compress is set and the
+// response allows it
+func (w *gzipResponseWriter) start(compress bool) error {
+	header := w.Header()
+	// Set the type from the uncompressed bytes; net/http would otherwise sniff gzip data
+	if header.Get("Content-Type") == "" && len(w.buf) > 0 {
+		header.Set("Content-Type", http.DetectContentType(w.buf))
+	}
+	// Already-encoded bodies, images (already compressed), partial content and bodiless
+	// statuses are left alone
+	if header.Get("Content-Encoding") != "" || header.Get("Content-Range") != "" ||
+		strings.HasPrefix(header.Get("Content-Type"), "image/") ||
+		w.status == http.StatusPartialContent || w.status == http.StatusNoContent || w.status == http.StatusNotModified {
+		compress = false
+	}
+
+	buf := w.buf
+	w.buf = nil
+	if !compress {
+		w.passthrough = true
+		w.ResponseWriter.WriteHeader(w.status)
+		_, err := w.ResponseWriter.Write(buf)
+		return err
+	}
+
+	header.Set("Content-Encoding", "gzip")
+	header.Del("Content-Length")
+	w.ResponseWriter.WriteHeader(w.status)
+	w.gz = gzip.NewWriter(w.ResponseWriter)
+	_, err := w.gz.Write(buf)
+	return err
+}
+
+// finish completes the response: small bodies go out uncompressed, compressed ones are
+// terminated
+func (w *gzipResponseWriter) finish() {
+	switch {
+	case w.gz != nil:
+		w.gz.Close()
+	case !w.passthrough:
+		w.start(false)
+	}
+}
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds optional service settings loaded from the environment
+type Config struct {
+	// ResultWebhookURL, when set, receives the scored landmarks for enrichment
+	ResultWebhookURL string
+	// ResultWebhookTimeout bounds the whole webhook round trip
+	ResultWebhookTimeout time.Duration
+	// ResultCacheTTL is how long scored landmark sets are kept for cursor pagination
+	ResultCacheTTL time.Duration
+	// ResultCacheStaleTTL is how long after expiry a cached set may still be served if a refresh fails
+	ResultCacheStaleTTL time.Duration
+	// GeocodeCacheTTL is how long forward geocoding results are cached
+	GeocodeCacheTTL time.Duration
+	// ResultCacheMaxEntries and GeocodeCacheMaxEntries bound each cache; the least recently
+	// used entry is evicted beyond that. 0 means unbounded.
+	ResultCacheMaxEntries  int
+	GeocodeCacheMaxEntries int
+	// CityAliases maps alternate city names to canonical ones for PIN validation
+	CityAliases map[string]string
+	// OriginNameMatchThreshold is the name similarity (0-1) at or above which a result is
+	// treated as the searched place itself and excluded
+	OriginNameMatchThreshold float64
+	// ScoreDecimals is the number of decimals popularity scores are rounded to in responses,
+	// within [0, maxScoreDecimals]
+	ScoreDecimals int
+	// KeywordSynonyms expands a search keyword into extra searches merged by place ID
+	KeywordSynonyms map[string][]string
+	// KeywordExpansionLimit caps the total searches one keyword may expand into
+	KeywordExpansionLimit int
+	// AdminAPIKey protects the /admin endpoints; they are disabled when empty
+	AdminAPIKey string
+	// RetryableStatuses are the Maps API statuses considered transient
+	RetryableStatuses map[string]bool
+	// MaxSuggestions caps the city suggestions in a validation response
+	MaxSuggestions int
+	// ParamAliases maps alternate request parameter names to canonical ones, e.g. "pincode" to "pin_code"
+	ParamAliases map[string]string
+	// AddressConflictPolicy decides which input is used when a request has both an address
+	// and a PIN code + city: PreferAddress, PreferPinCode or CrossValidate
+	AddressConflictPolicy string
+	// AddressConflictDistance is how far apart, in meters, cross-validated inputs may resolve
+	// before a warning is added
+	AddressConflictDistance float64
+	// DefaultSearchTypes are the place types searched, and merged, when a request names
+	// neither types nor a keyword
+	DefaultSearchTypes []string
+	// ServiceCountries, when non-empty, restricts the service to locations in these countries
+	// (lowercase names or ISO codes)
+	ServiceCountries map[string]bool
+	// MaxLandmarksLimit caps the per-request landmark limit
+	MaxLandmarksLimit int
+	// PostalComponentMode controls the postal-code component filter on PIN geocoding:
+	// PostalComponentStrict, PostalComponentLoose or PostalComponentOmit
+	PostalComponentMode string
+	// PostalCodeCountry is the ISO country code whose postal-code format PIN codes are
+	// checked against before geocoding
+	PostalCodeCountry string
+	// NearbyProviders are the place search providers tried for landmark searches, in order
+	NearbyProviders []string
+	// NearbyProviderMode is ProviderModeFirstSuccess or ProviderModeMerge
+	NearbyProviderMode string
+	// TypeDominanceThreshold is the share (0-1) of a page one category may exceed before an
+	// unfiltered search gets a warning
+	TypeDominanceThreshold float64
+	// NearbyMaxPages caps the Nearby Search result pages (20 results each) fetched per search
+	NearbyMaxPages int
+	// Debug adds diagnostic fields, such as the geocode queries sent, to responses
+	Debug bool
+	// MaxSearchRadius is the largest landmark search radius in meters; larger requests are
+	// clamped to it. Never above maxNearbyRadius.
+	MaxSearchRadius float64
+	// QuietHours is a daily window during which caches are preferred over live Maps calls;
+	// nil when unset
+	QuietHours *quietWindow
+	// QuietHoursTTLFactor multiplies cache TTLs during quiet hours
+	QuietHoursTTLFactor float64
+	// DefaultLanguage is the Maps result language when a request names none or an
+	// unsupported one
+	DefaultLanguage string
+	// RateLimitPerMinute caps /api/ requests per client IP per minute; 0 disables the limit
+	RateLimitPerMinute int
+	// PhotoMaxWidth is the maxwidth, in pixels, of landmark photo URLs (1 to 1600)
+	PhotoMaxWidth int
+	// ShutdownGracePeriod is how long in-flight requests may take to finish on SIGINT/SIGTERM
+	ShutdownGracePeriod time.Duration
+	// MetricsEnabled serves Prometheus metrics on /metrics
+	MetricsEnabled bool
+	// Score holds the popularity formula weights
+	Score ScoreConfig
+	// TrustForwardedFor identifies clients by X-Forwarded-For instead of the connection's
+	// address, for deployments behind a proxy
+	TrustForwardedFor bool
+	// CORSAllowedOrigins are the browser origins allowed to call the API, normalized by
+	// normalizeOrigin; empty allows every origin
+	CORSAllowedOrigins map[string]bool
+	// CORSAllowedMethods and CORSAllowedHeaders are sent in the CORS allow headers
+	CORSAllowedMethods string
+	CORSAllowedHeaders string
+}
+
+// maxNearbyRadius is the largest radius Google's Nearby Search accepts, in meters
+const maxNearbyRadius = 50000
+
+// maxScoreDecimals bounds SCORE_DECIMALS; float64 scores carry no meaningful digits beyond it
+const maxScoreDecimals = 6
+
+// loadConfig reads service settings from environment variables, falling back to defaults
+func loadConfig() Config {
+	return Config{
+		ResultWebhookURL:         os.Getenv("RESULT_WEBHOOK_URL"),
+		ResultWebhookTimeout:     getEnvDuration("RESULT_WEBHOOK_TIMEOUT", 2*time.Second),
+		ResultCacheTTL:           getEnvDuration("RESULT_CACHE_TTL", 5*time.Minute),
+		ResultCacheStaleTTL:      getEnvDuration("RESULT_CACHE_STALE_TTL", 30*time.Minute),
+		GeocodeCacheTTL:          getEnvDuration("GEOCODE_CACHE_TTL", 24*time.Hour),
+		ResultCacheMaxEntries:    max(getEnvInt("RESULT_CACHE_MAX_ENTRIES", 1000), 0),
+		GeocodeCacheMaxEntries:   max(getEnvInt("GEOCODE_CACHE_MAX_ENTRIES", 10000), 0),
+		CityAliases:              parseCityAliases(os.Getenv("CITY_ALIASES")),
+		OriginNameMatchThreshold: getEnvFloat("ORIGIN_NAME_MATCH_THRESHOLD", 0.8),
+		ScoreDecimals:            getEnvIntInRange("SCORE_DECIMALS", 2, 0, maxScoreDecimals),
+		KeywordSynonyms:          parseKeywordSynonyms(os.Getenv("KEYWORD_SYNONYMS")),
+		KeywordExpansionLimit:    max(getEnvInt("KEYWORD_EXPANSION_LIMIT", 3), 1),
+		AdminAPIKey:              os.Getenv("ADMIN_API_KEY"),
+		RetryableStatuses:        parseStatusList(os.Getenv("RETRYABLE_STATUSES"), defaultRetryableStatuses),
+		MaxSuggestions:           max(getEnvInt("MAX_SUGGESTIONS", 5), 1),
+		ParamAliases:             parseParamAliases(os.Getenv("PARAM_ALIASES")),
+		AddressConflictPolicy:    parseConflictPolicy(os.Getenv("ADDRESS_CONFLICT_POLICY")),
+		AddressConflictDistance:  getEnvFloat("ADDRESS_CONFLICT_DISTANCE", 2000),
+		DefaultSearchTypes:       parseSearchStrategy(os.Getenv("DEFAULT_SEARCH_STRATEGY")),
+		ServiceCountries:         parseCountryList(os.Getenv("SERVICE_COUNTRIES")),
+		MaxLandmarksLimit:        max(getEnvInt("MAX_LANDMARKS_LIMIT", 20), 1),
+		PostalComponentMode:      parsePostalComponentMode(os.Getenv("POSTAL_COMPONENT_MODE")),
+		PostalCodeCountry:        parsePostalCodeCountry(os.Getenv("POSTAL_CODE_COUNTRY")),
+		NearbyProviders:          parseProviderChain(os.Getenv("NEARBY_PROVIDERS")),
+		NearbyProviderMode:       parseProviderMode(os.Getenv("NEARBY_PROVIDER_MODE")),
+		TypeDominanceThreshold:   getEnvFloat("TYPE_DOMINANCE_THRESHOLD", 0.6),
+		NearbyMaxPages:           min(max(getEnvInt("NEARBY_MAX_PAGES", 3), 1), 3),
+		Debug:                    os.Getenv("DEBUG") == "true",
+		MaxSearchRadius:          min(max(getEnvFloat("MAX_SEARCH_RADIUS", maxNearbyRadius), 1), maxNearbyRadius),
+		QuietHours:               parseQuietHours(os.Getenv("QUIET_HOURS"), getEnvString("QUIET_HOURS_TZ", "Asia/Kolkata")),
+		QuietHoursTTLFactor:      max(getEnvFloat("QUIET_HOURS_TTL_FACTOR", 4), 1),
+		DefaultLanguage:          parseDefaultLanguage(os.Getenv("DEFAULT_LANGUAGE")),
+		RateLimitPerMinute:       max(getEnvInt("RATE_LIMIT_PER_MINUTE", 30), 0),
+		TrustForwardedFor:        os.Getenv("TRUST_FORWARDED_FOR") == "true",
+		ShutdownGracePeriod:      getEnvDuration("SHUTDOWN_GRACE_PERIOD", 15*time.Second),
+		MetricsEnabled:           os.Getenv("METRICS_ENABLED") == "true",
+		CORSAllowedOrigins:       parseOriginList(os.Getenv("CORS_ALLOWED_ORIGINS")),
+		CORSAllowedMethods:       getEnvString("CORS_ALLOWED_METHODS", defaultCORSMethods),
+		CORSAllowedHeaders:       getEnvString("CORS_ALLOWED_HEADERS", defaultCORSHeaders),
+		PhotoMaxWidth:            min(max(getEnvInt("PHOTO_MAX_WIDTH", 400), 1), maxPhotoWidth),
+		Score: ScoreConfig{
+			RatingWeight:    max(getEnvFloat("SCORE_RATING_WEIGHT", defaultScoreConfig.RatingWeight), 0),
+			ReviewWeight:    max(getEnvFloat("SCORE_REVIEW_WEIGHT", defaultScoreConfig.ReviewWeight), 0),
+			DistancePenalty: max(getEnvFloat("SCORE_DISTANCE_PENALTY", defaultScoreConfig.DistancePenalty), 0),
+		},
+	}
+}
+
+// getEnvString returns an env var, or def when unset
+func getEnvString(key, def string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return def
+}
+
+// getEnvInt parses an integer env var, returning def when unset or invalid
+func getEnvInt(key string, def int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Invalid %s=%q, using default %d", key, value, def)
+		return def
+	}
+	return n
+}
+
+// getEnvIntInRange parses an integer env var like getEnvInt, clamping it to [lo, hi] with a
+// warning when it is out of range
+func getEnvIntInRange(key string, def, lo, hi int) int {
+	n := getEnvInt(key, def)
+	if clamped := min(max(n, lo), hi); clamped != n {
+		log.Printf("%s=%d is out of range [%d, %d], using %d", key, n, lo, hi, clamped)
+		return clamped
+	}
+	return n
+}
+
+// getEnvFloat parses a float env var, returning def when unset or invalid
+func getEnvFloat(key string, def float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		log.Printf("Invalid %s=%q, using default %v", key, value, def)
+		return def
+	}
+	return f
+}
+
+// getEnvDuration parses a duration env var such as "2s", returning def when unset or invalid
+func getEnvDuration(key string, def time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Printf("Invalid %s=%q, using default %s", key, value, def)
+		return def
+	}
+	return d
+}
+
+// Default search strategies, selected with DEFAULT_SEARCH_STRATEGY
+const (
+	SearchStrategyBroad   = "broad"   // one point_of_interest search
+	SearchStrategyCurated = "curated" // one search per curatedSearchTypes entry, merged
+)
+
+// curatedSearchTypes are the place types searched by the curated default strategy
+var curatedSearchTypes = []string{"tourist_attraction", "restaurant", "park"}
+
+// parseSearchStrategy returns the place types searched when a request names neither
+// types nor a keyword, defaulting to the broad strategy
+func parseSearchStrategy(value string) []string {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", SearchStrategyBroad:
+		return []string{"point_of_interest"}
+	case SearchStrategyCurated:
+		return curatedSearchTypes
+	default:
+		log.Printf("Invalid DEFAULT_SEARCH_STRATEGY=%q, using default %s", value, SearchStrategyBroad)
+		return []string{"point_of_interest"}
+	}
+}
+
+// Postal-code component modes for PIN geocoding, selected with POSTAL_COMPONENT_MODE
+const (
+	PostalComponentStrict = "strict" // only postal-code matches
+	PostalComponentLoose  = "loose"  // postal-code matches, else any match for the PIN text
+	PostalComponentOmit   = "omit"   // any match for the PIN text
+)
+
+// parsePostalComponentMode returns the mode named by value, defaulting to strict
+func parsePostalComponentMode(value string) string {
+	switch mode := strings.ToLower(strings.TrimSpace(value)); mode {
+	case "":
+		return PostalComponentStrict
+	case PostalComponentStrict, PostalComponentLoose, PostalComponentOmit:
+		return mode
+	default:
+		log.Printf("Invalid POSTAL_COMPONENT_MODE=%q, using default %s", value, PostalComponentStrict)
+		return PostalComponentStrict
+	}
+}
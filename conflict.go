@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Policies for requests that give both an address and a PIN code + city
+const (
+	PreferAddress = "prefer_address" // geocode the address, ignore the PIN code
+	PreferPinCode = "prefer_pincode" // geocode the PIN code, ignore the address
+	CrossValidate = "cross_validate" // geocode both, warn if far apart, use the more precise one
+)
+
+// parseConflictPolicy returns the policy named by value, defaulting to PreferAddress
+func parseConflictPolicy(value string) string {
+	switch policy := strings.ToLower(strings.TrimSpace(value)); policy {
+	case "":
+		return PreferAddress
+	case PreferAddress, PreferPinCode, CrossValidate:
+		return policy
+	default:
+		log.Printf("Invalid ADDRESS_CONFLICT_POLICY=%q, using default %s", value, PreferAddress)
+		return PreferAddress
+	}
+}
+
+// confidenceRank orders confidence levels so they can be compared
+var confidenceRank = map[string]int{ConfidenceLow: 0, ConfidenceMedium: 1, ConfidenceHigh: 2}
+
+// resolveConflictingCenter picks the search center for a request that gives both an
+// address and a PIN code + city, according to Config.AddressConflictPolicy. country applies
+// to the PIN code only, see pinCodeCenter.
+func (s *LocationService) resolveConflictingCenter(ctx context.Context, address, pinCode, city, country string) (*searchCenter, *LandmarksResponse, error) {
+	switch s.config.AddressConflictPolicy {
+	case PreferPinCode:
+		return s.pinCodeCenter(ctx, pinCode, city, country)
+	case CrossValidate:
+		return s.crossValidatedCenter(ctx, address, pinCode, city, country)
+	default:
+		return s.addressCenter(ctx, address)
+	}
+}
+
+// crossValidatedCenter geocodes both the address and the PIN code + city, warns when they
+// resolve more than Config.AddressConflictDistance apart, and uses the more precise one
+func (s *LocationService) crossValidatedCenter(ctx context.Context, address, pinCode, city, country string) (*searchCenter, *LandmarksResponse, error) {
+	fromAddress, addressFailure, err := s.addressCenter(ctx, address)
+	if err != nil {
+		return nil, nil, err
+	}
+	fromPinCode, pinFailure, err := s.pinCodeCenter(ctx, pinCode, city, country)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// If only one input resolves, use it and say why the other was dropped
+	if addressFailure != nil && pinFailure != nil {
+		return nil, addressFailure, nil
+	}
+	if addressFailure != nil {
+		fromPinCode.Warnings = append(fromPinCode.Warnings, fmt.Sprintf("Address ignored: %s", addressFailure.Message))
+		return fromPinCode, nil, nil
+	}
+	if pinFailure != nil {
+		fromAddress.Warnings = append(fromAddress.Warnings, fmt.Sprintf("PIN code ignored: %s", pinFailure.Message))
+		return fromAddress, nil, nil
+	}
+
+	// Prefer the address on equal confidence, matching the default policy
+	chosen := fromAddress
+	if confidenceRank[fromPinCode.Confidence] > confidenceRank[fromAddress.Confidence] {
+		chosen = fromPinCode
+	}
+
+	// The offset is kept in meters and converted to the requested unit when the response is
+	// built, so the warning refers to it rather than embedding a unit-specific number
+	distance := calculateDistance(
+		fromAddress.Location.Lat, fromAddress.Location.Lng,
+		fromPinCode.Location.Lat, fromPinCode.Location.Lng,
+	)
+	chosen.Offset = &distance
+	if distance > s.config.AddressConflictDistance {
+		source := "address"
+		if chosen == fromPinCode {
+			source = "PIN code"
+		}
+		chosen.Warnings = append(chosen.Warnings, fmt.Sprintf(
+			"Address and PIN code resolve far apart (see center_offset); searching around the more precise %s",
+			source,
+		))
+	}
+	return chosen, nil, nil
+}
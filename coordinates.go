@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+)
+
+// ErrCodeInvalidCoordinates is returned with a 400 when a request carries unusable lat/lng values
+const ErrCodeInvalidCoordinates = "INVALID_COORDINATES"
+
+// validateCoordinates checks that lat is within [-90, 90], lng within [-180, 180] and neither
+// is NaN or infinite, so bad input never reaches the Haversine math or the Maps API
+func validateCoordinates(lat, lng float64) error {
+	if math.IsNaN(lat) || math.IsInf(lat, 0) || math.IsNaN(lng) || math.IsInf(lng, 0) {
+		return fmt.Errorf("coordinates must be finite numbers")
+	}
+	if lat < -90 || lat > 90 {
+		return fmt.Errorf("latitude %v is out of range [-90, 90]", lat)
+	}
+	if lng < -180 || lng > 180 {
+		return fmt.Errorf("longitude %v is out of range [-180, 180]", lng)
+	}
+	return nil
+}
+
+// writeInvalidCoordinates sends the uniform 400 response for a coordinate validation error
+func writeInvalidCoordinates(w http.ResponseWriter, err error) {
+	writeError(w, http.StatusBadRequest, ErrCodeInvalidCoordinates, err.Error())
+}
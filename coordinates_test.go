@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidateCoordinates(t *testing.T) {
+	tests := []struct {
+		name     string
+		lat, lng float64
+		wantErr  bool
+	}{
+		{"origin", 0, 0, false},
+		{"north pole", 90, 0, false},
+		{"south pole", -90, 0, false},
+		{"antimeridian east", 0, 180, false},
+		{"antimeridian west", 0, -180, false},
+		{"corner", -90, 180, false},
+		{"latitude just above 90", 90.000001, 0, true},
+		{"latitude just below -90", -90.000001, 0, true},
+		{"longitude just above 180", 0, 180.000001, true},
+		{"longitude just below -180", 0, -180.000001, true},
+		{"NaN latitude", math.NaN(), 0, true},
+		{"NaN longitude", 0, math.NaN(), true},
+		{"infinite latitude", math.Inf(1), 0, true},
+		{"negative infinite latitude", math.Inf(-1), 0, true},
+		{"infinite longitude", 0, math.Inf(1), true},
+		{"negative infinite longitude", 0, math.Inf(-1), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateCoordinates(tt.lat, tt.lng); (err != nil) != tt.wantErr {
+				t.Errorf("validateCoordinates(%v, %v) = %v, want error %v", tt.lat, tt.lng, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWriteInvalidCoordinates(t *testing.T) {
+	w := httptest.NewRecorder()
+	writeInvalidCoordinates(w, errors.New("latitude 91 is out of range [-90, 90]"))
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	var body ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body %s is not an ErrorResponse: %v", w.Body, err)
+	}
+	want := ErrorResponse{Error: true, Message: "latitude 91 is out of range [-90, 90]", Code: ErrCodeInvalidCoordinates}
+	if body != want {
+		t.Errorf("body = %+v, want %+v", body, want)
+	}
+}
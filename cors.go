@@ -0,0 +1,61 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Default CORS methods and headers, used when CORS_ALLOWED_METHODS or CORS_ALLOWED_HEADERS
+// is unset
+const (
+	defaultCORSMethods = "POST, GET, OPTIONS"
+	defaultCORSHeaders = "Content-Type, X-Include-API-Calls, X-Request-ID"
+)
+
+// parseOriginList parses a comma-separated list of origins such as
+// "https://shop.example.com" into a set. Origins are compared case-insensitively and without
+// a trailing slash; an empty list allows every origin.
+func parseOriginList(value string) map[string]bool {
+	origins := make(map[string]bool)
+	for _, origin := range strings.Split(value, ",") {
+		if origin = normalizeOrigin(origin); origin != "" {
+			origins[origin] = true
+		}
+	}
+	return origins
+}
+
+func normalizeOrigin(origin string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(origin)), "/")
+}
+
+// corsMiddleware adds CORS headers and answers preflight requests. With no allowed origins
+// configured every origin gets "*"; otherwise a listed request Origin is echoed back and any
+// other origin gets no CORS headers, so browsers block its calls.
+func corsMiddleware(config Config, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		allowOrigin := "*"
+		if len(config.CORSAllowedOrigins) > 0 {
+			// The response depends on Origin, so caches must not share it across origins
+			w.Header().Add("Vary", "Origin")
+			allowOrigin = ""
+			if origin := r.Header.Get("Origin"); config.CORSAllowedOrigins[normalizeOrigin(origin)] {
+				allowOrigin = origin
+			}
+		}
+
+		if allowOrigin != "" {
+			w.Header().Set("Access-Control-Allow-Origin", allowOrigin)
+			w.Header().Set("Access-Control-Allow-Methods", config.CORSAllowedMethods)
+			w.Header().Set("Access-Control-Allow-Headers", config.CORSAllowedHeaders)
+			w.Header().Set("Access-Control-Expose-Headers", "X-Request-ID")
+		}
+
+		if r.Method == "OPTIONS" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
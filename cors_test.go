@@ -0,0 +1,62 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCORSMiddleware(t *testing.T) {
+	config := Config{
+		CORSAllowedOrigins: parseOriginList("https://shop.example.com/, https://admin.example.com"),
+		CORSAllowedMethods: defaultCORSMethods,
+		CORSAllowedHeaders: defaultCORSHeaders,
+	}
+	tests := []struct {
+		name       string
+		config     Config
+		method     string
+		origin     string
+		wantOrigin string
+		wantVary   bool
+		wantStatus int
+		wantServed bool
+	}{
+		{"allowed origin", config, http.MethodGet, "https://Shop.Example.com", "https://Shop.Example.com", true, http.StatusTeapot, true},
+		{"disallowed origin", config, http.MethodGet, "https://evil.example.com", "", true, http.StatusTeapot, true},
+		{"allowed preflight", config, http.MethodOptions, "https://admin.example.com", "https://admin.example.com", true, http.StatusOK, false},
+		{"disallowed preflight", config, http.MethodOptions, "https://evil.example.com", "", true, http.StatusOK, false},
+		{"no origins configured", Config{CORSAllowedMethods: defaultCORSMethods}, http.MethodGet, "https://evil.example.com", "*", false, http.StatusTeapot, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			served := false
+			handler := corsMiddleware(tt.config, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				served = true
+				w.WriteHeader(http.StatusTeapot)
+			}))
+			r := httptest.NewRequest(tt.method, "/api/get-landmarks", nil)
+			r.Header.Set("Origin", tt.origin)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.wantOrigin)
+			}
+			gotMethods := w.Header().Get("Access-Control-Allow-Methods")
+			if (gotMethods != "") != (tt.wantOrigin != "") {
+				t.Errorf("Access-Control-Allow-Methods = %q, want it only for allowed origins", gotMethods)
+			}
+			if got := w.Header().Get("Vary") == "Origin"; got != tt.wantVary {
+				t.Errorf("Vary: Origin = %v, want %v", got, tt.wantVary)
+			}
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if served != tt.wantServed {
+				t.Errorf("handler called = %v, want %v", served, tt.wantServed)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"googlemaps.github.io/maps"
+)
+
+// CountryNotAllowedError reports that a location resolved outside Config.ServiceCountries
+type CountryNotAllowedError struct {
+	Country string
+}
+
+func (e *CountryNotAllowedError) Error() string {
+	if e.Country == "" {
+		return "this service only covers specific countries, and the location's country could not be determined"
+	}
+	return fmt.Sprintf("locations in %s are not covered by this service", e.Country)
+}
+
+// isCountryNotAllowed reports whether err was caused by a location outside the served countries
+func isCountryNotAllowed(err error) bool {
+	var notAllowed *CountryNotAllowedError
+	return errors.As(err, &notAllowed)
+}
+
+// parseCountryList parses a comma-separated list of country names or ISO codes into a
+// lowercase set; an empty list means every country is served
+func parseCountryList(value string) map[string]bool {
+	countries := make(map[string]bool)
+	for _, country := range strings.Split(value, ",") {
+		if country = strings.ToLower(strings.TrimSpace(country)); country != "" {
+			countries[country] = true
+		}
+	}
+	return countries
+}
+
+// checkServiceCountry returns a CountryNotAllowedError unless the geocoding result lies in
+// one of Config.ServiceCountries, matched by country name or ISO code
+func (s *LocationService) checkServiceCountry(result maps.GeocodingResult) error {
+	if len(s.config.ServiceCountries) == 0 {
+		return nil
+	}
+
+	var country string
+	for _, component := range result.AddressComponents {
+		for _, typ := range component.Types {
+			if typ != "country" {
+				continue
+			}
+			if s.config.ServiceCountries[strings.ToLower(component.LongName)] ||
+				s.config.ServiceCountries[strings.ToLower(component.ShortName)] {
+				return nil
+			}
+			country = component.LongName
+		}
+	}
+	return &CountryNotAllowedError{Country: country}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// pageCursor identifies the last landmark a client has already received
+type pageCursor struct {
+	Score   float64 `json:"s"`
+	PlaceID string  `json:"id"`
+	Rank    int     `json:"r,omitempty"`
+}
+
+// encodeCursor turns a landmark into an opaque "load more" token
+func encodeCursor(landmark Landmark) string {
+	data, _ := json.Marshal(pageCursor{Score: landmark.PopScore, PlaceID: landmark.PlaceID, Rank: landmark.Rank})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor parses a token produced by encodeCursor
+func decodeCursor(token string) (pageCursor, error) {
+	var cursor pageCursor
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor, errors.New("invalid cursor")
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.PlaceID == "" {
+		return cursor, errors.New("invalid cursor")
+	}
+	return cursor, nil
+}
+
+// cursorOffset returns the index of the first landmark after the cursor. If the cursor's
+// place is no longer present (e.g. the cached set expired and was rebuilt), a score-sorted
+// list resumes after the last landmark with a score at or above the cursor's, and any other
+// order resumes after the cursor's rank.
+func cursorOffset(landmarks []Landmark, cursor pageCursor, byScore bool) int {
+	for i, landmark := range landmarks {
+		if landmark.PlaceID == cursor.PlaceID {
+			return i + 1
+		}
+	}
+	if !byScore {
+		return min(cursor.Rank, len(landmarks))
+	}
+	for i, landmark := range landmarks {
+		if landmark.PopScore < cursor.Score {
+			return i
+		}
+	}
+	return len(landmarks)
+}
+
+// fingerprint identifies the scored result set a request produces.
+// Paging and per-page enrichment options don't change the set and are excluded.
+func (r GetLandmarksRequest) fingerprint() string {
+	r.Cursor = ""
+	r.Limit = 0
+	r.Units = ""
+	r.IncludeDetails = false
+	r.IncludePhotos = false
+	r.IncludeReviewSampleBreakdown = false
+	r.IncludeRoadDistance = false
+	r.PreferQuiet = false
+	r.IncludeWalkability = false
+	r.IncludeWalkingTime = false
+	r.TravelMode = ""
+	if ranksByScore(r.SortBy) {
+		r.SortBy = ""
+	}
+	r.TypesLimit = 0
+	r.SpecificTypesOnly = false
+	r.PinCode = strings.TrimSpace(r.PinCode)
+	r.City = strings.ToLower(strings.TrimSpace(r.City))
+	r.Country = normalizeCountry(r.Country)
+	r.Address = strings.ToLower(strings.TrimSpace(r.Address))
+	data, _ := json.Marshal(r)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
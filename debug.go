@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"sync"
+
+	"googlemaps.github.io/maps"
+)
+
+// GeocodeQuery is the input side of one forward geocode: the address text and component
+// filters as sent to Google. Cached is true when the geocode cache answered instead.
+type GeocodeQuery struct {
+	Address    string            `json:"address"`
+	Components map[string]string `json:"components,omitempty"`
+	Cached     bool              `json:"cached"`
+}
+
+// geocodeTraceKey is the context key for a request's geocodeTrace
+type geocodeTraceKey struct{}
+
+// geocodeTrace collects the geocode queries made while serving one request. Lookups can
+// run concurrently, e.g. cross-validating an address and a PIN code, hence the lock.
+type geocodeTrace struct {
+	mu      sync.Mutex
+	queries []GeocodeQuery
+}
+
+// withGeocodeTrace returns a context under which s.geocode records its queries in trace
+func withGeocodeTrace(ctx context.Context) (_ context.Context, trace *geocodeTrace) {
+	trace = &geocodeTrace{}
+	return context.WithValue(ctx, geocodeTraceKey{}, trace), trace
+}
+
+// recordGeocodeQuery adds req to the context's trace, if it has one
+func recordGeocodeQuery(ctx context.Context, req *maps.GeocodingRequest, cached bool) {
+	trace, ok := ctx.Value(geocodeTraceKey{}).(*geocodeTrace)
+	if !ok {
+		return
+	}
+	query := GeocodeQuery{Address: req.Address, Cached: cached}
+	if len(req.Components) > 0 {
+		query.Components = make(map[string]string, len(req.Components))
+		for component, value := range req.Components {
+			query.Components[string(component)] = value
+		}
+	}
+	trace.mu.Lock()
+	trace.queries = append(trace.queries, query)
+	trace.mu.Unlock()
+}
+
+// Queries returns the recorded queries in the order they were made
+func (t *geocodeTrace) Queries() []GeocodeQuery {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.queries
+}
+
+// debugContext attaches a geocode trace to ctx when Config.Debug is on; trace is nil otherwise
+func (s *LocationService) debugContext(ctx context.Context) (context.Context, *geocodeTrace) {
+	if !s.config.Debug {
+		return ctx, nil
+	}
+	return withGeocodeTrace(ctx)
+}
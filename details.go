@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"log"
+	"slices"
+	"strconv"
+	"sync"
+
+	"googlemaps.github.io/maps"
+)
+
+// maxDetailsConcurrency bounds parallel Place Details lookups per request
+const maxDetailsConcurrency = 5
+
+// maxLandmarkPhotos caps the photo URLs returned per landmark
+const maxLandmarkPhotos = 3
+
+// detailsFields is the Place Details field mask requested for the details enrichment. Phone
+// number, website and opening hours are billed as Contact Data on top of Basic Data.
+var detailsFields = []maps.PlaceDetailsFieldMask{
+	maps.PlaceDetailsFieldMaskFormattedAddress,
+	maps.PlaceDetailsFieldMaskFormattedPhoneNumber,
+	maps.PlaceDetailsFieldMaskWebsite,
+	maps.PlaceDetailsFieldMaskOpeningHours,
+}
+
+// detailsOptions selects which Place Details enrichments to run in one lookup per landmark
+type detailsOptions struct {
+	// Details sets FormattedAddress, PhoneNumber, Website and OpeningHours
+	Details bool
+	// ReviewSampleBreakdown requests reviews to build Landmark.ReviewSampleBreakdown
+	ReviewSampleBreakdown bool
+	// Open24Hours requests opening hours to set Open24Hours
+	Open24Hours bool
+	// Photos sets Photos
+	Photos bool
+}
+
+// fields returns the Place Details field mask covering the selected enrichments
+func (o detailsOptions) fields() []maps.PlaceDetailsFieldMask {
+	var fields []maps.PlaceDetailsFieldMask
+	add := func(field maps.PlaceDetailsFieldMask) {
+		if !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+	if o.Details {
+		for _, field := range detailsFields {
+			add(field)
+		}
+	}
+	if o.ReviewSampleBreakdown {
+		add(maps.PlaceDetailsFieldMaskReviews)
+	}
+	if o.Open24Hours {
+		add(maps.PlaceDetailsFieldMaskOpeningHours)
+	}
+	if o.Photos {
+		add(maps.PlaceDetailsFieldMaskPhotos)
+	}
+	return fields
+}
+
+// enrichWithDetails fills in the Place Details fields selected by opts on each landmark in
+// place. Lookups run concurrently; a failed lookup leaves that landmark unchanged.
+func (s *LocationService) enrichWithDetails(ctx context.Context, landmarks []Landmark, opts detailsOptions) {
+	fields := opts.fields()
+	if len(fields) == 0 {
+		return
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxDetailsConcurrency)
+
+	for i := range landmarks {
+		wg.Add(1)
+		go func(landmark *Landmark) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return
+			}
+
+			details, err := s.mapsClient.PlaceDetails(ctx, &maps.PlaceDetailsRequest{
+				PlaceID:  landmark.PlaceID,
+				Fields:   fields,
+				Language: s.languageFrom(ctx),
+			})
+			if err != nil {
+				log.Printf("Place details failed for %s: %v", landmark.PlaceID, err)
+				return
+			}
+
+			if opts.Details {
+				landmark.FormattedAddress = details.FormattedAddress
+				landmark.PhoneNumber = details.FormattedPhoneNumber
+				landmark.Website = details.Website
+				if details.OpeningHours != nil {
+					landmark.OpeningHours = details.OpeningHours.WeekdayText
+				}
+			}
+			if opts.ReviewSampleBreakdown {
+				landmark.ReviewSampleBreakdown = reviewSampleBreakdown(details.Reviews)
+			}
+			if opts.Open24Hours {
+				open24Hours := isOpen24Hours(details.OpeningHours)
+				landmark.Open24Hours = &open24Hours
+			}
+			if opts.Photos {
+				landmark.Photos = s.photoURLs(details.Photos)
+			}
+		}(&landmarks[i])
+	}
+
+	wg.Wait()
+}
+
+// photoURLs builds /api/photo proxy URLs for the first maxLandmarkPhotos photos, at most
+// Config.PhotoMaxWidth pixels wide, so browsers can load them without the API key
+func (s *LocationService) photoURLs(photos []maps.Photo) []string {
+	var urls []string
+	for _, photo := range photos[:min(len(photos), maxLandmarkPhotos)] {
+		urls = append(urls, photoProxyURL(photo.PhotoReference, s.config.PhotoMaxWidth))
+	}
+	return urls
+}
+
+// reviewSampleBreakdown counts reviews per star level, keyed "1" to "5". Google returns at
+// most five "most relevant" reviews per place, so this is a small sample that can disagree
+// with the place's overall rating, not its full histogram. Returns nil when there are no
+// reviews.
+func reviewSampleBreakdown(reviews []maps.PlaceReview) map[string]int {
+	if len(reviews) == 0 {
+		return nil
+	}
+	breakdown := make(map[string]int)
+	for _, review := range reviews {
+		if review.Rating >= 1 && review.Rating <= 5 {
+			breakdown[strconv.Itoa(review.Rating)]++
+		}
+	}
+	return breakdown
+}
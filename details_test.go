@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"googlemaps.github.io/maps"
+)
+
+func TestReviewSampleBreakdown(t *testing.T) {
+	reviews := []maps.PlaceReview{{Rating: 5}, {Rating: 5}, {Rating: 4}, {Rating: 1}, {Rating: 0}}
+	want := map[string]int{"5": 2, "4": 1, "1": 1}
+	if got := reviewSampleBreakdown(reviews); !reflect.DeepEqual(got, want) {
+		t.Errorf("reviewSampleBreakdown = %v, want %v", got, want)
+	}
+	if got := reviewSampleBreakdown(nil); got != nil {
+		t.Errorf("reviewSampleBreakdown(nil) = %v, want nil", got)
+	}
+}
+
+func TestReviewSampleBreakdownOldParameterName(t *testing.T) {
+	client := newAddressClient([]maps.PlacesSearchResult{fakePlace("Museum", 4.5, 1000, 200)})
+	client.details = map[string]maps.PlaceDetailsResult{
+		"id-Museum": {Reviews: []maps.PlaceReview{{Rating: 5}, {Rating: 3}}},
+	}
+	service := newTestService(t, client)
+
+	var req GetLandmarksRequest
+	body := `{"address": "1 Mall Road", "include_details": true, "include_rating_breakdown": true}`
+	if err := service.decodeJSON(strings.NewReader(body), &req); err != nil {
+		t.Fatalf("decodeJSON: %v", err)
+	}
+	response, err := service.GetNearbyLandmarks(context.Background(), req)
+	if err != nil {
+		t.Fatalf("GetNearbyLandmarks: %v", err)
+	}
+	if len(response.Landmarks) != 1 {
+		t.Fatalf("got %d landmarks, want 1", len(response.Landmarks))
+	}
+
+	data, err := json.Marshal(response.Landmarks[0])
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if !strings.Contains(string(data), `"review_sample_breakdown":{"3":1,"5":1}`) {
+		t.Errorf("landmark JSON %s lacks the review sample breakdown", data)
+	}
+	if !slices.Contains(response.Enrichments, EnrichmentReviewSampleBreakdown) {
+		t.Errorf("enrichments = %v, want %s", response.Enrichments, EnrichmentReviewSampleBreakdown)
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type DistanceRequest struct {
+	From *Location `json:"from"`
+	To   *Location `json:"to"`
+}
+
+type DistanceResponse struct {
+	// Distance is the straight-line Haversine distance, computed exactly as landmark distances are
+	Distance float64 `json:"distance"`
+	Unit     string  `json:"unit"`
+}
+
+// handleDistance returns the distance between two points without any Maps API call
+func (s *LocationService) handleDistance(w http.ResponseWriter, r *http.Request) {
+	var req DistanceRequest
+	if err := s.decodeJSON(r.Body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid request body")
+		return
+	}
+
+	if req.From == nil || req.To == nil {
+		writeInvalidCoordinates(w, fmt.Errorf("from and to are required"))
+		return
+	}
+	for _, point := range []*Location{req.From, req.To} {
+		if err := validateCoordinates(point.Lat, point.Lng); err != nil {
+			writeInvalidCoordinates(w, err)
+			return
+		}
+	}
+
+	writeResponse(w, r, &DistanceResponse{
+		Distance: calculateDistance(req.From.Lat, req.From.Lng, req.To.Lat, req.To.Lng),
+		Unit:     UnitMeters,
+	})
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+)
+
+// minDominanceResults is the fewest landmarks a page needs before one type can be said to
+// dominate it
+const minDominanceResults = 3
+
+// dominantCategory returns the primary category making up more than threshold of the
+// landmarks, and its share, or "" when none does
+func dominantCategory(landmarks []Landmark, threshold float64) (category string, share float64) {
+	if len(landmarks) < minDominanceResults {
+		return "", 0
+	}
+	counts := make(map[string]int)
+	for _, landmark := range landmarks {
+		if c := primaryCategory(landmark.Types); c != "" {
+			counts[c]++
+		}
+	}
+	for c, count := range counts {
+		if s := float64(count) / float64(len(landmarks)); s > threshold && s > share {
+			category, share = c, s
+		}
+	}
+	return category, share
+}
+
+// withDominanceWarning appends a warning to warnings when an unfiltered search's page is
+// mostly one category. The ranking is left alone; the warning only suggests a better query.
+// warnings may be shared with the result cache, so it is never appended to in place.
+func (s *LocationService) withDominanceWarning(warnings []string, req GetLandmarksRequest, landmarks []Landmark) []string {
+	if len(req.Types) > 0 || len(req.TypeFallbackChain) > 0 || req.Keyword != "" {
+		return warnings
+	}
+	category, share := dominantCategory(landmarks, s.config.TypeDominanceThreshold)
+	if category == "" {
+		return warnings
+	}
+	return append(slices.Clip(warnings), fmt.Sprintf(
+		"%.0f%% of these landmarks are of type %s; set types to several categories for a more varied mix",
+		share*100, category,
+	))
+}
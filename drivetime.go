@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"googlemaps.github.io/maps"
+)
+
+// distanceMatrixAPI is the Google Maps Platform API used for travel times
+const distanceMatrixAPI = "Distance Matrix API"
+
+// maxMatrixDestinations is Google's limit on destinations per Distance Matrix call
+const maxMatrixDestinations = 25
+
+// maxDriveTimeGridSide caps the cells per grid row and column, so a grid costs at most
+// maxDriveTimeGridSide² Distance Matrix elements
+const maxDriveTimeGridSide = 15
+
+// maxDriveTimeGridExtent caps how far the grid reaches from the center, in meters
+const maxDriveTimeGridExtent = 50000
+
+type DriveTimeGridRequest struct {
+	PinCode string `json:"pin_code,omitempty"`
+	City    string `json:"city,omitempty"`
+	Address string `json:"address,omitempty"`
+	// Lat/Lng can be given instead of an address or PIN code
+	Lat *float64 `json:"lat,omitempty"`
+	Lng *float64 `json:"lng,omitempty"`
+	// Spacing is the distance between neighboring cells in meters (default 500)
+	Spacing float64 `json:"spacing,omitempty"`
+	// Extent is how far the grid reaches from the center in each direction, in meters (default 2000)
+	Extent float64 `json:"extent,omitempty"`
+}
+
+type DriveTimeGridResponse struct {
+	Success  bool     `json:"success"`
+	Message  string   `json:"message"`
+	Location Location `json:"location"`
+	// Rows run north to south, and the cells in each row west to east
+	Rows [][]DriveTimeCell `json:"rows"`
+}
+
+type DriveTimeCell struct {
+	Location Location `json:"location"`
+	// Reachable is false when Google found no driving route to the cell
+	Reachable bool `json:"reachable"`
+	// DurationSeconds and DistanceMeters describe the driving route from the center
+	DurationSeconds float64 `json:"duration_seconds,omitempty"`
+	DistanceMeters  int     `json:"distance_meters,omitempty"`
+}
+
+// driveTimeGridSide returns the number of cells per grid row and column
+func driveTimeGridSide(spacing, extent float64) int {
+	return 2*int(math.Floor(extent/spacing)) + 1
+}
+
+// validateDriveTimeGrid checks the grid dimensions after defaults are applied
+func validateDriveTimeGrid(spacing, extent float64) error {
+	if spacing <= 0 || extent <= 0 {
+		return errors.New("spacing and extent must be positive")
+	}
+	if extent > maxDriveTimeGridExtent {
+		return fmt.Errorf("extent cannot exceed %d meters", maxDriveTimeGridExtent)
+	}
+	if side := driveTimeGridSide(spacing, extent); side > maxDriveTimeGridSide {
+		return fmt.Errorf("grid would be %dx%d cells; at most %dx%d are allowed", side, side, maxDriveTimeGridSide, maxDriveTimeGridSide)
+	}
+	return nil
+}
+
+// GetDriveTimeGrid computes driving times from the center to a square grid of points around it
+func (s *LocationService) GetDriveTimeGrid(ctx context.Context, req DriveTimeGridRequest) (*DriveTimeGridResponse, error) {
+	var center maps.LatLng
+	if req.Lat != nil && req.Lng != nil {
+		center = maps.LatLng{Lat: *req.Lat, Lng: *req.Lng}
+	} else {
+		resolved, failure, err := s.resolveSearchCenter(ctx, GetLandmarksRequest{
+			PinCode: req.PinCode,
+			City:    req.City,
+			Address: req.Address,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if failure != nil {
+			return &DriveTimeGridResponse{Success: false, Message: failure.Message}, nil
+		}
+		center = resolved.Location
+	}
+
+	side := driveTimeGridSide(req.Spacing, req.Extent)
+	half := side / 2
+	points := make([]maps.LatLng, 0, side*side)
+	for row := 0; row < side; row++ {
+		north := float64(half-row) * req.Spacing
+		for col := 0; col < side; col++ {
+			east := float64(col-half) * req.Spacing
+			points = append(points, offsetPoint(offsetPoint(center, 0, north), 90, east))
+		}
+	}
+
+	elements, err := s.travelMatrix(ctx, center, points, maps.TravelModeDriving)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][]DriveTimeCell, side)
+	reachable := 0
+	for i, point := range points {
+		cell := DriveTimeCell{Location: Location{Lat: point.Lat, Lng: point.Lng}}
+		if element := elements[i]; element.Status == "OK" {
+			cell.Reachable = true
+			cell.DurationSeconds = element.Duration.Seconds()
+			cell.DistanceMeters = element.Distance.Meters
+			reachable++
+		}
+		rows[i/side] = append(rows[i/side], cell)
+	}
+
+	return &DriveTimeGridResponse{
+		Success:  true,
+		Message:  fmt.Sprintf("Computed drive times for %d of %d cells", reachable, len(points)),
+		Location: Location{Lat: center.Lat, Lng: center.Lng},
+		Rows:     rows,
+	}, nil
+}
+
+// travelMatrix returns the routes from origin to each destination by the given travel mode,
+// in destination order. Google allows at most maxMatrixDestinations destinations per call
+// (and bills per element), so longer lists are split into chunks fetched concurrently.
+func (s *LocationService) travelMatrix(ctx context.Context, origin maps.LatLng, destinations []maps.LatLng, mode maps.Mode) ([]maps.DistanceMatrixElement, error) {
+	elements := make([]maps.DistanceMatrixElement, len(destinations))
+	var chunks [][2]int
+	for start := 0; start < len(destinations); start += maxMatrixDestinations {
+		chunks = append(chunks, [2]int{start, min(start+maxMatrixDestinations, len(destinations))})
+	}
+
+	errs := make([]error, len(chunks))
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		wg.Add(1)
+		go func(i, start, end int) {
+			defer wg.Done()
+			errs[i] = s.travelTimes(ctx, origin, destinations[start:end], mode, elements[start:end])
+		}(i, chunk[0], chunk[1])
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return elements, nil
+}
+
+// travelTimes fills elements with the routes from origin to each destination by the given
+// travel mode. At most maxMatrixDestinations destinations may be passed.
+func (s *LocationService) travelTimes(ctx context.Context, origin maps.LatLng, destinations []maps.LatLng, mode maps.Mode, elements []maps.DistanceMatrixElement) error {
+	req := &maps.DistanceMatrixRequest{
+		Origins: []string{origin.String()},
+		Mode:    mode,
+	}
+	for _, destination := range destinations {
+		req.Destinations = append(req.Destinations, destination.String())
+	}
+
+	resp, err := s.mapsClient.DistanceMatrix(ctx, req)
+	if err != nil {
+		return fmt.Errorf("distance matrix failed: %w", checkAPIEnabled(distanceMatrixAPI, err))
+	}
+	if len(resp.Rows) == 0 {
+		return nil
+	}
+	for i, element := range resp.Rows[0].Elements {
+		if i < len(elements) && element != nil {
+			elements[i] = *element
+		}
+	}
+	return nil
+}
+
+func (s *LocationService) handleDriveTimeGrid(w http.ResponseWriter, r *http.Request) {
+	var req DriveTimeGridRequest
+	if err := s.decodeJSON(r.Body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid request body")
+		return
+	}
+
+	if (req.Lat == nil) != (req.Lng == nil) {
+		writeInvalidCoordinates(w, fmt.Errorf("lat and lng must be given together"))
+		return
+	}
+	if req.Lat != nil {
+		if err := validateCoordinates(*req.Lat, *req.Lng); err != nil {
+			writeInvalidCoordinates(w, err)
+			return
+		}
+	}
+
+	if req.Spacing == 0 {
+		req.Spacing = 500
+	}
+	if req.Extent == 0 {
+		req.Extent = 2000
+	}
+	if err := validateDriveTimeGrid(req.Spacing, req.Extent); err != nil {
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid grid: "+err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	response, err := s.GetDriveTimeGrid(ctx, req)
+	if err != nil {
+		writeServiceError(w, err, "Failed to compute drive times")
+		return
+	}
+
+	writeResponse(w, r, response)
+}
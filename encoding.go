@@ -0,0 +1,366 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MessagePack media types accepted in the Accept header
+const (
+	contentTypeMsgpack  = "application/msgpack"
+	contentTypeXMsgpack = "application/x-msgpack"
+)
+
+// writeResponse encodes v in the format the client asked for via the Accept header:
+// MessagePack for application/msgpack, JSON otherwise
+func writeResponse(w http.ResponseWriter, r *http.Request, v interface{}) {
+	if acceptsMsgpack(r) {
+		data, err := marshalMsgpack(v)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to encode response: "+err.Error())
+			return
+		}
+		w.Header().Set("Content-Type", contentTypeMsgpack)
+		w.Write(data)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// acceptsMsgpack reports whether the request's Accept header lists a MessagePack media type
+func acceptsMsgpack(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, _ := strings.Cut(part, ";")
+		switch strings.TrimSpace(strings.ToLower(mediaType)) {
+		case contentTypeMsgpack, contentTypeXMsgpack:
+			return true
+		}
+	}
+	return false
+}
+
+// marshalMsgpack encodes v as MessagePack. Structs are encoded from their fields using the
+// json tags, so field names and omitempty match the JSON response, but numbers keep their Go
+// types: float fields stay floats even when whole, ints stay ints. Values with a custom
+// MarshalJSON, such as time.Time, are encoded from their JSON instead.
+func marshalMsgpack(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeMsgpack(&buf, reflect.ValueOf(v)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+var jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+
+// writeMsgpack encodes v as MessagePack, following encoding/json's rules for what to emit
+func writeMsgpack(buf *bytes.Buffer, v reflect.Value) error {
+	if !v.IsValid() {
+		buf.WriteByte(0xc0)
+		return nil
+	}
+	if v.Type().Implements(jsonMarshalerType) {
+		if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+			buf.WriteByte(0xc0)
+			return nil
+		}
+		return writeMsgpackJSON(buf, v.Interface().(json.Marshaler))
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			buf.WriteByte(0xc0)
+			return nil
+		}
+		return writeMsgpack(buf, v.Elem())
+	case reflect.Bool:
+		if v.Bool() {
+			buf.WriteByte(0xc3)
+		} else {
+			buf.WriteByte(0xc2)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeMsgpackInt(buf, v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if u := v.Uint(); u > math.MaxInt64 {
+			buf.WriteByte(0xcf)
+			binary.Write(buf, binary.BigEndian, u)
+		} else {
+			writeMsgpackInt(buf, int64(u))
+		}
+	case reflect.Float32:
+		buf.WriteByte(0xca)
+		binary.Write(buf, binary.BigEndian, math.Float32bits(float32(v.Float())))
+	case reflect.Float64:
+		buf.WriteByte(0xcb)
+		binary.Write(buf, binary.BigEndian, math.Float64bits(v.Float()))
+	case reflect.String:
+		writeMsgpackString(buf, v.String())
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			buf.WriteByte(0xc0)
+			return nil
+		}
+		// Byte slices are base64 strings, as in the JSON response
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			writeMsgpackString(buf, base64.StdEncoding.EncodeToString(v.Bytes()))
+			return nil
+		}
+		writeMsgpackHeader(buf, v.Len(), 0x90, 0xdc, 0xdd)
+		for i := 0; i < v.Len(); i++ {
+			if err := writeMsgpack(buf, v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		return writeMsgpackMap(buf, v)
+	case reflect.Struct:
+		return writeMsgpackStruct(buf, v)
+	default:
+		return fmt.Errorf("msgpack: unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// writeMsgpackMap writes a map with string or integer keys, sorted so the encoding is
+// deterministic
+func writeMsgpackMap(buf *bytes.Buffer, v reflect.Value) error {
+	if v.IsNil() {
+		buf.WriteByte(0xc0)
+		return nil
+	}
+	keys := make([]string, 0, v.Len())
+	values := make(map[string]reflect.Value, v.Len())
+	for iter := v.MapRange(); iter.Next(); {
+		var key string
+		switch k := iter.Key(); k.Kind() {
+		case reflect.String:
+			key = k.String()
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			key = strconv.FormatInt(k.Int(), 10)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			key = strconv.FormatUint(k.Uint(), 10)
+		default:
+			return fmt.Errorf("msgpack: unsupported map key type %s", k.Type())
+		}
+		keys = append(keys, key)
+		values[key] = iter.Value()
+	}
+	sort.Strings(keys)
+
+	writeMsgpackHeader(buf, len(keys), 0x80, 0xde, 0xdf)
+	for _, key := range keys {
+		writeMsgpackString(buf, key)
+		if err := writeMsgpack(buf, values[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// msgpackField is a struct field to encode, named as in its json tag
+type msgpackField struct {
+	name      string
+	value     reflect.Value
+	omitEmpty bool
+}
+
+// writeMsgpackStruct writes a struct as a map of its exported fields in declaration order.
+// Untagged embedded structs are flattened into the parent, like in encoding/json.
+func writeMsgpackStruct(buf *bytes.Buffer, v reflect.Value) error {
+	var fields []msgpackField
+	for _, field := range msgpackFields(v) {
+		if field.omitEmpty && isEmptyValue(field.value) {
+			continue
+		}
+		fields = append(fields, field)
+	}
+
+	writeMsgpackHeader(buf, len(fields), 0x80, 0xde, 0xdf)
+	for _, field := range fields {
+		writeMsgpackString(buf, field.name)
+		if err := writeMsgpack(buf, field.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isEmptyValue reports whether omitempty drops v: false, 0, nil, or an empty string, slice,
+// map or array. Structs are never empty, as in encoding/json.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return v.Len() == 0
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Pointer, reflect.Interface:
+		return v.IsZero()
+	}
+	return false
+}
+
+// msgpackFields lists the fields of struct v that encoding/json would emit
+func msgpackFields(v reflect.Value) []msgpackField {
+	var fields []msgpackField
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := v.Field(i)
+			if embedded.Kind() == reflect.Pointer {
+				if embedded.IsNil() {
+					continue
+				}
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				fields = append(fields, msgpackFields(embedded)...)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, msgpackField{
+			name:      name,
+			value:     v.Field(i),
+			omitEmpty: slices.Contains(strings.Split(options, ","), "omitempty"),
+		})
+	}
+	return fields
+}
+
+// writeMsgpackJSON encodes a value with a custom MarshalJSON from its JSON encoding. Numbers
+// in that JSON have no Go type, so whole numbers become integers and others float64.
+func writeMsgpackJSON(buf *bytes.Buffer, m json.Marshaler) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var generic interface{}
+	if err := dec.Decode(&generic); err != nil {
+		return err
+	}
+	return writeMsgpackGeneric(buf, generic)
+}
+
+// writeMsgpackGeneric encodes a value decoded from JSON (with UseNumber) as MessagePack
+func writeMsgpackGeneric(buf *bytes.Buffer, v interface{}) error {
+	switch val := v.(type) {
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			writeMsgpackInt(buf, i)
+			return nil
+		}
+		f, err := val.Float64()
+		if err != nil {
+			return err
+		}
+		buf.WriteByte(0xcb)
+		binary.Write(buf, binary.BigEndian, math.Float64bits(f))
+	case []interface{}:
+		writeMsgpackHeader(buf, len(val), 0x90, 0xdc, 0xdd)
+		for _, item := range val {
+			if err := writeMsgpackGeneric(buf, item); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		writeMsgpackHeader(buf, len(val), 0x80, 0xde, 0xdf)
+		for _, k := range keys {
+			writeMsgpackString(buf, k)
+			if err := writeMsgpackGeneric(buf, val[k]); err != nil {
+				return err
+			}
+		}
+	default:
+		return writeMsgpack(buf, reflect.ValueOf(v))
+	}
+	return nil
+}
+
+// writeMsgpackInt writes i using the smallest MessagePack integer encoding
+func writeMsgpackInt(buf *bytes.Buffer, i int64) {
+	switch {
+	case i >= 0 && i <= 127:
+		buf.WriteByte(byte(i))
+	case i < 0 && i >= -32:
+		buf.WriteByte(byte(i))
+	case i >= math.MinInt8 && i <= math.MaxInt8:
+		buf.WriteByte(0xd0)
+		buf.WriteByte(byte(int8(i)))
+	case i >= math.MinInt16 && i <= math.MaxInt16:
+		buf.WriteByte(0xd1)
+		binary.Write(buf, binary.BigEndian, int16(i))
+	case i >= math.MinInt32 && i <= math.MaxInt32:
+		buf.WriteByte(0xd2)
+		binary.Write(buf, binary.BigEndian, int32(i))
+	default:
+		buf.WriteByte(0xd3)
+		binary.Write(buf, binary.BigEndian, i)
+	}
+}
+
+// writeMsgpackString writes a UTF-8 string with the smallest str header
+func writeMsgpackString(buf *bytes.Buffer, s string) {
+	n := len(s)
+	switch {
+	case n <= 31:
+		buf.WriteByte(0xa0 | byte(n))
+	case n <= math.MaxUint8:
+		buf.WriteByte(0xd9)
+		buf.WriteByte(byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(0xda)
+		binary.Write(buf, binary.BigEndian, uint16(n))
+	default:
+		buf.WriteByte(0xdb)
+		binary.Write(buf, binary.BigEndian, uint32(n))
+	}
+	buf.WriteString(s)
+}
+
+// writeMsgpackHeader writes an array or map header: a fix-type byte for up to 15
+// elements, otherwise the 16- or 32-bit length form
+func writeMsgpackHeader(buf *bytes.Buffer, n int, fix, code16, code32 byte) {
+	switch {
+	case n <= 15:
+		buf.WriteByte(fix | byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(code16)
+		binary.Write(buf, binary.BigEndian, uint16(n))
+	default:
+		buf.WriteByte(code32)
+		binary.Write(buf, binary.BigEndian, uint32(n))
+	}
+}
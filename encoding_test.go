@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"googlemaps.github.io/maps"
+)
+
+// msgpackReader decodes the MessagePack subset marshalMsgpack writes. Integers decode to
+// int64 and floats to float64, so tests can tell them apart.
+type msgpackReader struct {
+	t    *testing.T
+	data []byte
+}
+
+func (m *msgpackReader) next(n int) []byte {
+	m.t.Helper()
+	if len(m.data) < n {
+		m.t.Fatalf("msgpack: truncated, need %d more bytes", n)
+	}
+	b := m.data[:n]
+	m.data = m.data[n:]
+	return b
+}
+
+func (m *msgpackReader) value() interface{} {
+	m.t.Helper()
+	b := m.next(1)[0]
+	switch {
+	case b <= 0x7f:
+		return int64(b)
+	case b >= 0xe0:
+		return int64(int8(b))
+	case b&0xe0 == 0xa0:
+		return string(m.next(int(b & 0x1f)))
+	case b&0xf0 == 0x90:
+		return m.array(int(b & 0x0f))
+	case b&0xf0 == 0x80:
+		return m.object(int(b & 0x0f))
+	}
+	switch b {
+	case 0xc0:
+		return nil
+	case 0xc2:
+		return false
+	case 0xc3:
+		return true
+	case 0xca:
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(m.next(4))))
+	case 0xcb:
+		return math.Float64frombits(binary.BigEndian.Uint64(m.next(8)))
+	case 0xcf:
+		return binary.BigEndian.Uint64(m.next(8))
+	case 0xd0:
+		return int64(int8(m.next(1)[0]))
+	case 0xd1:
+		return int64(int16(binary.BigEndian.Uint16(m.next(2))))
+	case 0xd2:
+		return int64(int32(binary.BigEndian.Uint32(m.next(4))))
+	case 0xd3:
+		return int64(binary.BigEndian.Uint64(m.next(8)))
+	case 0xd9:
+		return string(m.next(int(m.next(1)[0])))
+	case 0xda:
+		return string(m.next(int(binary.BigEndian.Uint16(m.next(2)))))
+	case 0xdc:
+		return m.array(int(binary.BigEndian.Uint16(m.next(2))))
+	case 0xde:
+		return m.object(int(binary.BigEndian.Uint16(m.next(2))))
+	}
+	m.t.Fatalf("msgpack: unexpected type byte %#x", b)
+	return nil
+}
+
+func (m *msgpackReader) array(n int) []interface{} {
+	items := make([]interface{}, n)
+	for i := range items {
+		items[i] = m.value()
+	}
+	return items
+}
+
+func (m *msgpackReader) object(n int) map[string]interface{} {
+	m.t.Helper()
+	fields := make(map[string]interface{}, n)
+	for i := 0; i < n; i++ {
+		key, ok := m.value().(string)
+		if !ok {
+			m.t.Fatal("msgpack: map key is not a string")
+		}
+		fields[key] = m.value()
+	}
+	return fields
+}
+
+func decodeMsgpack(t *testing.T, data []byte) interface{} {
+	t.Helper()
+	m := &msgpackReader{t: t, data: data}
+	v := m.value()
+	if len(m.data) != 0 {
+		t.Fatalf("msgpack: %d trailing bytes", len(m.data))
+	}
+	return v
+}
+
+func TestMarshalMsgpackKeepsFloats(t *testing.T) {
+	response := &LandmarksResponse{
+		Success:        true,
+		Location:       Location{Lat: 26, Lng: 80},
+		RawResultCount: 3,
+		GeneratedAt:    time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC),
+		Unit:           UnitKilometers,
+		Landmarks:      []Landmark{{Name: "Fort", Distance: 2, Rating: 4, UserRatings: 100}},
+	}
+	data, err := marshalMsgpack(response)
+	if err != nil {
+		t.Fatalf("marshalMsgpack: %v", err)
+	}
+	got := decodeMsgpack(t, data).(map[string]interface{})
+
+	if location := got["location"].(map[string]interface{}); location["lat"] != float64(26) || location["lng"] != float64(80) {
+		t.Errorf("location = %#v, want float64 26 and 80", location)
+	}
+	if got["raw_result_count"] != int64(3) {
+		t.Errorf("raw_result_count = %#v, want int64 3", got["raw_result_count"])
+	}
+	landmark := got["landmarks"].([]interface{})[0].(map[string]interface{})
+	if landmark["distance"] != float64(2) || landmark["rating"] != float64(4) || landmark["user_ratings_total"] != int64(100) {
+		t.Errorf("landmark = %#v, want float distance and rating, int review count", landmark)
+	}
+	if got["generated_at"] != "2026-03-01T09:30:00Z" {
+		t.Errorf("generated_at = %#v, want the JSON time string", got["generated_at"])
+	}
+	if got["unit"] != UnitKilometers {
+		t.Errorf("unit = %#v, want %q", got["unit"], UnitKilometers)
+	}
+	if _, ok := got["next_cursor"]; ok {
+		t.Error("next_cursor is present, want it omitted when empty like in JSON")
+	}
+}
+
+// TestMarshalMsgpackMatchesJSON checks that a full search response has the same fields and
+// values in both encodings
+func TestMarshalMsgpackMatchesJSON(t *testing.T) {
+	client := newAddressClient([]maps.PlacesSearchResult{
+		fakePlace("Fort", 4.0, 100, 500),
+		fakePlace("Museum", 4.5, 1000, 200),
+	})
+	response, err := newTestService(t, client).GetNearbyLandmarks(context.Background(), GetLandmarksRequest{Address: "1 Mall Road"})
+	if err != nil {
+		t.Fatalf("GetNearbyLandmarks: %v", err)
+	}
+
+	data, err := marshalMsgpack(response)
+	if err != nil {
+		t.Fatalf("marshalMsgpack: %v", err)
+	}
+	fromMsgpack := asJSONNumbers(decodeMsgpack(t, data))
+
+	jsonData, _ := json.Marshal(response)
+	var fromJSON interface{}
+	json.Unmarshal(jsonData, &fromJSON)
+
+	if !reflect.DeepEqual(fromMsgpack, fromJSON) {
+		t.Errorf("MessagePack decodes to\n%v\nJSON to\n%v", fromMsgpack, fromJSON)
+	}
+}
+
+// asJSONNumbers converts the numbers in a decoded MessagePack value to float64, as
+// encoding/json decodes them
+func asJSONNumbers(v interface{}) interface{} {
+	switch val := v.(type) {
+	case int64:
+		return float64(val)
+	case []interface{}:
+		for i := range val {
+			val[i] = asJSONNumbers(val[i])
+		}
+	case map[string]interface{}:
+		for k := range val {
+			val[k] = asJSONNumbers(val[k])
+		}
+	}
+	return v
+}
+
+func TestWriteResponseMsgpack(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/api/distance", nil)
+	r.Header.Set("Accept", "application/json;q=0.5, application/msgpack")
+	w := httptest.NewRecorder()
+	writeResponse(w, r, &DistanceResponse{Distance: 1200, Unit: UnitMeters})
+
+	if got := w.Header().Get("Content-Type"); got != contentTypeMsgpack {
+		t.Errorf("Content-Type = %q, want %q", got, contentTypeMsgpack)
+	}
+	want := map[string]interface{}{"distance": float64(1200), "unit": UnitMeters}
+	if got := decodeMsgpack(t, w.Body.Bytes()); !reflect.DeepEqual(got, want) {
+		t.Errorf("body = %#v, want %#v", got, want)
+	}
+}
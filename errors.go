@@ -0,0 +1,42 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Error codes for ErrorResponse.Code, alongside ErrCodeAPINotEnabled and ErrCodeInvalidCoordinates
+const (
+	ErrCodeInvalidRequest    = "INVALID_REQUEST"     // malformed body or out-of-range parameter
+	ErrCodeCountryNotAllowed = "COUNTRY_NOT_ALLOWED" // location outside Config.ServiceCountries
+	ErrCodeInternal          = "INTERNAL_ERROR"      // unexpected failure, e.g. a Maps API error
+	ErrCodeUnauthorized      = "UNAUTHORIZED"        // missing or wrong X-Admin-Key
+)
+
+// ErrorResponse is the JSON body of a failed request, so clients can always parse responses
+// as JSON
+type ErrorResponse struct {
+	Error   bool   `json:"error"`
+	Message string `json:"message"`
+	Code    string `json:"code"`
+}
+
+// writeError sends an ErrorResponse with the given HTTP status
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(ErrorResponse{Error: true, Message: message, Code: code})
+}
+
+// writeServiceError maps an error from the service layer to an ErrorResponse: 503 for a
+// disabled Maps API, 403 for an unserved country and 500, prefixed with action, otherwise
+func writeServiceError(w http.ResponseWriter, err error, action string) {
+	switch {
+	case isAPINotEnabled(err):
+		writeError(w, http.StatusServiceUnavailable, ErrCodeAPINotEnabled, err.Error())
+	case isCountryNotAllowed(err):
+		writeError(w, http.StatusForbidden, ErrCodeCountryNotAllowed, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, ErrCodeInternal, action+": "+err.Error())
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandlersSendJSONErrors(t *testing.T) {
+	service := newTestService(t, &fakeMapsClient{})
+	service.config.AdminAPIKey = "secret"
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		body    string
+		status  int
+		code    string
+	}{
+		{"distance with a malformed body", service.handleDistance, `{`, http.StatusBadRequest, ErrCodeInvalidRequest},
+		{"distance without points", service.handleDistance, `{}`, http.StatusBadRequest, ErrCodeInvalidCoordinates},
+		{"rings out of order", service.handleRingCounts, `{"address": "1 Mall Road", "rings": [2000, 1000]}`, http.StatusBadRequest, ErrCodeInvalidRequest},
+		{"ring under a meter", service.handleRingCounts, `{"address": "1 Mall Road", "rings": [0.5]}`, http.StatusBadRequest, ErrCodeInvalidRequest},
+		{"drive time grid too large", service.handleDriveTimeGrid, `{"lat": 26.4, "lng": 80.3, "extent": 1000000}`, http.StatusBadRequest, ErrCodeInvalidRequest},
+		{"reverse geocode out of range", service.handleReverseGeocode, `{"lat": 91, "lng": 80.3}`, http.StatusBadRequest, ErrCodeInvalidCoordinates},
+		{"reverse geocode Maps failure", service.handleReverseGeocode, `{"lat": 26.4, "lng": 80.3}`, http.StatusInternalServerError, ErrCodeInternal},
+		{"batch without requests", service.handleStreamBatchLandmarks, `{"requests": []}`, http.StatusBadRequest, ErrCodeInvalidRequest},
+		{"admin with a wrong key", service.requireAdminKey(service.handleCacheStats), ``, http.StatusUnauthorized, ErrCodeUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/api/test", strings.NewReader(tt.body))
+			r.Header.Set("X-Admin-Key", "wrong")
+			w := httptest.NewRecorder()
+			tt.handler(w, r)
+
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+			if got := w.Header().Get("Content-Type"); got != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", got)
+			}
+			var body ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body %q is not JSON: %v", w.Body.String(), err)
+			}
+			if !body.Error || body.Code != tt.code || body.Message == "" {
+				t.Errorf("body = %+v, want an error with code %s", body, tt.code)
+			}
+		})
+	}
+}
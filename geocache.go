@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"googlemaps.github.io/maps"
+)
+
+// geocode runs a forward geocode through the geocode cache, retrying transient failures.
+// Results, including empty ones, are cached by normalized input for Config.GeocodeCacheTTL;
+// errors are not cached.
+// Callers wrap errors themselves, as they do for direct client calls.
+func (s *LocationService) geocode(ctx context.Context, req *maps.GeocodingRequest) ([]maps.GeocodingResult, error) {
+	req.Language = s.languageFrom(ctx)
+	key := geocodeCacheKey(req)
+	if results, ok := s.geocodeCache.Get(key); ok {
+		recordGeocodeQuery(ctx, req, true)
+		return results, nil
+	}
+	recordGeocodeQuery(ctx, req, false)
+
+	results, err := withRetry(ctx, s, func() ([]maps.GeocodingResult, error) {
+		return s.mapsClient.Geocode(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.geocodeCache.Set(key, results)
+	return results, nil
+}
+
+// geocodeCacheKey normalizes a geocode request's address and component filters so that
+// requests differing only in case or spacing share an entry
+func geocodeCacheKey(req *maps.GeocodingRequest) string {
+	parts := []string{strings.Join(strings.Fields(strings.ToLower(req.Address)), " ")}
+	components := make([]string, 0, len(req.Components))
+	for component, value := range req.Components {
+		components = append(components, fmt.Sprintf("%s:%s", component, strings.ToLower(strings.TrimSpace(value))))
+	}
+	sort.Strings(components)
+	parts = append(parts, components...)
+	parts = append(parts, req.Region, req.Language)
+	return strings.Join(parts, "|")
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"googlemaps.github.io/maps"
+)
+
+// isOpen24Hours reports whether opening hours describe a place that never closes
+func isOpen24Hours(hours *maps.OpeningHours) bool {
+	if hours == nil || len(hours.Periods) == 0 {
+		return false
+	}
+
+	// Google marks always-open places with a single period opening Sunday 0000 and no close
+	if len(hours.Periods) == 1 {
+		period := hours.Periods[0]
+		return period.Open.Day == time.Sunday && period.Open.Time == "0000" && period.Close.Time == ""
+	}
+
+	// Otherwise every day must run from 0000 to 2359, or to 0000 the next day
+	days := make(map[time.Weekday]bool)
+	for _, period := range hours.Periods {
+		if period.Open.Time != "0000" {
+			return false
+		}
+		sameDay := period.Close.Day == period.Open.Day && period.Close.Time == "2359"
+		nextDay := period.Close.Day == (period.Open.Day+1)%7 && period.Close.Time == "0000"
+		if !sameDay && !nextDay {
+			return false
+		}
+		days[period.Open.Day] = true
+	}
+	return len(days) == 7
+}
+
+// maxOpen24HoursLookups caps the Place Details lookups one open_24_hours search makes, so a
+// search costs at most this many calls however many candidates Google returns
+const maxOpen24HoursLookups = 20
+
+// filterOpen24Hours keeps only the set's landmarks known to be open around the clock,
+// judged from opening hours looked up in Place Details for the first maxOpen24HoursLookups
+// landmarks, and ranks them anew. The rest are dropped and counted: in Not24Hours when the
+// place has other or no opening hours, in HoursUnknown when its lookup failed, and in
+// HoursUnchecked when it was beyond the lookup cap.
+func (s *LocationService) filterOpen24Hours(ctx context.Context, set *scoredSet) {
+	checked := set.Landmarks[:min(len(set.Landmarks), maxOpen24HoursLookups)]
+	s.enrichWithDetails(ctx, checked, detailsOptions{Open24Hours: true})
+
+	var kept []Landmark
+	for _, landmark := range checked {
+		switch {
+		case landmark.Open24Hours == nil:
+			// The lookup failed or timed out, which says nothing about the hours
+			set.HoursUnknown++
+		case *landmark.Open24Hours:
+			landmark.Rank = len(kept) + 1
+			kept = append(kept, landmark)
+		default:
+			set.Not24Hours++
+		}
+	}
+	set.HoursUnchecked = len(set.Landmarks) - len(checked)
+	set.Landmarks = kept
+}
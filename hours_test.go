@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"googlemaps.github.io/maps"
+)
+
+// alwaysOpen is how Google describes a place that never closes
+var alwaysOpen = &maps.OpeningHours{Periods: []maps.OpeningHoursPeriod{
+	{Open: maps.OpeningHoursOpenClose{Day: time.Sunday, Time: "0000"}},
+}}
+
+// officeHours opens 0900 to 1800 on Monday only
+var officeHours = &maps.OpeningHours{Periods: []maps.OpeningHoursPeriod{
+	{
+		Open:  maps.OpeningHoursOpenClose{Day: time.Monday, Time: "0900"},
+		Close: maps.OpeningHoursOpenClose{Day: time.Monday, Time: "1800"},
+	},
+}}
+
+// hoursClient is a fake whose places have the given opening hours; nil means none
+func hoursClient(hours map[string]*maps.OpeningHours, places ...maps.PlacesSearchResult) *fakeMapsClient {
+	client := newAddressClient(places)
+	client.details = make(map[string]maps.PlaceDetailsResult)
+	for _, place := range places {
+		client.details[place.PlaceID] = maps.PlaceDetailsResult{PlaceID: place.PlaceID, OpeningHours: hours[place.Name]}
+	}
+	return client
+}
+
+func TestOpen24HoursFiltersBeforePaging(t *testing.T) {
+	client := hoursClient(map[string]*maps.OpeningHours{
+		"Pharmacy": alwaysOpen,
+		"Bank":     officeHours,
+		"ATM":      alwaysOpen,
+		"Museum":   officeHours,
+		"Fuel":     alwaysOpen,
+	},
+		fakePlace("Pharmacy", 4.0, 100, 500), // score 5.35
+		fakePlace("Bank", 4.8, 5000, 500),    // 11.84
+		fakePlace("Museum", 4.5, 1000, 200),  // 11.25
+		fakePlace("ATM", 4.2, 50, 100),       // 6.52
+		fakePlace("Shop", 4.1, 800, 300),     // 9.16, no hours
+		fakePlace("Fuel", 3.9, 300, 1000),    // 4.83
+	)
+	service := newTestService(t, client)
+	req := GetLandmarksRequest{Address: "1 Mall Road", Open24Hours: true, IncludeDetails: true, Limit: 2}
+
+	first, err := service.GetNearbyLandmarks(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Cursor = first.NextCursor
+	second, err := service.GetNearbyLandmarks(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := landmarkNames(first.Landmarks); !reflect.DeepEqual(got, []string{"ATM", "Pharmacy"}) {
+		t.Errorf("first page = %v, want a full page [ATM Pharmacy]", got)
+	}
+	if got := landmarkNames(second.Landmarks); !reflect.DeepEqual(got, []string{"Fuel"}) {
+		t.Errorf("second page = %v, want [Fuel]", got)
+	}
+	if first.TotalAvailable != 3 {
+		t.Errorf("total_available = %d, want 3", first.TotalAvailable)
+	}
+	for i, landmark := range append(first.Landmarks, second.Landmarks...) {
+		if landmark.Rank != i+1 {
+			t.Errorf("%s: rank = %d, want %d", landmark.Name, landmark.Rank, i+1)
+		}
+		if landmark.Open24Hours == nil || !*landmark.Open24Hours {
+			t.Errorf("%s: open_24_hours not set", landmark.Name)
+		}
+	}
+}
+
+func TestOpen24HoursEmptyResultMessage(t *testing.T) {
+	client := hoursClient(map[string]*maps.OpeningHours{"Bank": officeHours},
+		fakePlace("Bank", 4.8, 5000, 500),
+		fakePlace("Shop", 4.1, 800, 300),
+	)
+	response, err := newTestService(t, client).GetNearbyLandmarks(context.Background(),
+		GetLandmarksRequest{Address: "1 Mall Road", Open24Hours: true, IncludeDetails: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Landmarks) != 0 {
+		t.Fatalf("landmarks = %v, want none", landmarkNames(response.Landmarks))
+	}
+	for _, want := range []string{"2 were not open 24 hours or had no opening hours", "turn off open_24_hours"} {
+		if !strings.Contains(response.Message, want) {
+			t.Errorf("message %q lacks %q", response.Message, want)
+		}
+	}
+}
+
+func TestOpen24HoursFailedLookupIsNotCached(t *testing.T) {
+	client := hoursClient(map[string]*maps.OpeningHours{"Pharmacy": alwaysOpen, "ATM": alwaysOpen},
+		fakePlace("Pharmacy", 4.0, 100, 500),
+		fakePlace("ATM", 4.2, 50, 100),
+	)
+	// The fake fails lookups of unknown place IDs
+	delete(client.details, "id-ATM")
+	service := newTestService(t, client)
+	req := GetLandmarksRequest{Address: "1 Mall Road", Open24Hours: true, IncludeDetails: true}
+
+	first, err := service.GetNearbyLandmarks(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := landmarkNames(first.Landmarks); !reflect.DeepEqual(got, []string{"Pharmacy"}) {
+		t.Errorf("landmarks = %v, want [Pharmacy]", got)
+	}
+	if !strings.Contains(first.Message, "opening hours of 1 places couldn't be looked up") {
+		t.Errorf("message %q doesn't report the failed lookup", first.Message)
+	}
+
+	// Once the lookup works, the next search sees the place instead of a cached cut-down set
+	client.details["id-ATM"] = maps.PlaceDetailsResult{PlaceID: "id-ATM", OpeningHours: alwaysOpen}
+	second, err := service.GetNearbyLandmarks(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := landmarkNames(second.Landmarks); !reflect.DeepEqual(got, []string{"ATM", "Pharmacy"}) {
+		t.Errorf("landmarks after recovery = %v, want [ATM Pharmacy]", got)
+	}
+	if got := client.callCount("nearby"); got != 2 {
+		t.Errorf("nearby calls = %d, want 2 (no cached set)", got)
+	}
+}
+
+func TestOpen24HoursCapsLookups(t *testing.T) {
+	hours := make(map[string]*maps.OpeningHours)
+	var places []maps.PlacesSearchResult
+	for i := 0; i < maxOpen24HoursLookups+5; i++ {
+		name := fmt.Sprintf("Place %02d", i)
+		hours[name] = alwaysOpen
+		places = append(places, fakePlace(name, 4.0, 100, float64(100+10*i)))
+	}
+	client := hoursClient(hours, places...)
+	response, err := newTestService(t, client).GetNearbyLandmarks(context.Background(),
+		GetLandmarksRequest{Address: "1 Mall Road", Open24Hours: true, IncludeDetails: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The page's own details lookups come on top of the capped hours lookups
+	if got, want := client.callCount("details"), maxOpen24HoursLookups+len(response.Landmarks); got != want {
+		t.Errorf("details calls = %d, want %d", got, want)
+	}
+	if response.TotalAvailable != maxOpen24HoursLookups {
+		t.Errorf("total_available = %d, want %d", response.TotalAvailable, maxOpen24HoursLookups)
+	}
+	if !strings.Contains(response.Message, fmt.Sprintf("only the first %d places were checked", maxOpen24HoursLookups)) {
+		t.Errorf("message %q doesn't mention the lookup cap", response.Message)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// jsonLDThing is a schema.org LocalBusiness or Place node
+type jsonLDThing struct {
+	Context         string             `json:"@context"`
+	Type            string             `json:"@type"`
+	Name            string             `json:"name"`
+	Address         jsonLDAddress      `json:"address"`
+	Geo             jsonLDGeo          `json:"geo"`
+	AggregateRating *jsonLDRating      `json:"aggregateRating,omitempty"`
+	URL             string             `json:"url,omitempty"`
+	Identifier      *jsonLDPropertyVal `json:"identifier,omitempty"`
+}
+
+type jsonLDAddress struct {
+	Type          string `json:"@type"`
+	StreetAddress string `json:"streetAddress"`
+}
+
+type jsonLDGeo struct {
+	Type      string  `json:"@type"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+type jsonLDRating struct {
+	Type        string  `json:"@type"`
+	RatingValue float32 `json:"ratingValue"`
+	ReviewCount int     `json:"reviewCount"`
+	BestRating  int     `json:"bestRating"`
+}
+
+type jsonLDPropertyVal struct {
+	Type       string `json:"@type"`
+	PropertyID string `json:"propertyID"`
+	Value      string `json:"value"`
+}
+
+// toJSONLD converts landmarks into schema.org JSON-LD nodes. Establishments become
+// LocalBusiness; everything else (parks, localities, natural features) is a Place. The
+// choice uses the full type list, so types_limit and specific_types_only don't change it.
+func toJSONLD(landmarks []Landmark) []jsonLDThing {
+	things := make([]jsonLDThing, 0, len(landmarks))
+	for _, landmark := range landmarks {
+		thingType := "Place"
+		for _, t := range landmark.fullTypes() {
+			if t == "establishment" {
+				thingType = "LocalBusiness"
+				break
+			}
+		}
+
+		address := landmark.FormattedAddress
+		if address == "" {
+			address = landmark.Address
+		}
+
+		thing := jsonLDThing{
+			Context: "https://schema.org",
+			Type:    thingType,
+			Name:    landmark.Name,
+			Address: jsonLDAddress{
+				Type:          "PostalAddress",
+				StreetAddress: address,
+			},
+			Geo: jsonLDGeo{
+				Type:      "GeoCoordinates",
+				Latitude:  landmark.Location.Lat,
+				Longitude: landmark.Location.Lng,
+			},
+		}
+
+		if landmark.UserRatings > 0 {
+			thing.AggregateRating = &jsonLDRating{
+				Type:        "AggregateRating",
+				RatingValue: landmark.Rating,
+				ReviewCount: landmark.UserRatings,
+				BestRating:  5,
+			}
+		}
+
+		if landmark.PlaceID != "" {
+			thing.URL = fmt.Sprintf("https://www.google.com/maps/search/?api=1&query=%f,%f&query_place_id=%s",
+				landmark.Location.Lat, landmark.Location.Lng, url.QueryEscape(landmark.PlaceID))
+			thing.Identifier = &jsonLDPropertyVal{
+				Type:       "PropertyValue",
+				PropertyID: "google_place_id",
+				Value:      landmark.PlaceID,
+			}
+		}
+
+		things = append(things, thing)
+	}
+	return things
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"googlemaps.github.io/maps"
+)
+
+func TestJSONLDTypeIgnoresTypeTrimming(t *testing.T) {
+	park := fakePlace("Company Bagh", 4.3, 900, 700)
+	park.Types = []string{"park", "point_of_interest"}
+	places := []maps.PlacesSearchResult{fakePlace("Fort", 4.0, 100, 500), park}
+
+	for _, query := range []string{
+		"",
+		"&specific_types_only=true",
+		"&types_limit=1",
+	} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/get-landmarks?address=1+Mall+Road&format=jsonld"+query, nil)
+		newTestService(t, newAddressClient(places)).handleGetLandmarks(w, r)
+
+		var things []jsonLDThing
+		if err := json.Unmarshal(w.Body.Bytes(), &things); err != nil {
+			t.Fatalf("%q: body %q is not JSON-LD: %v", query, w.Body, err)
+		}
+		got := map[string]string{}
+		for _, thing := range things {
+			got[thing.Name] = thing.Type
+		}
+		if got["Company Bagh"] != "Place" || got["Fort"] != "LocalBusiness" {
+			t.Errorf("%q: @types = %v, want Company Bagh a Place and Fort a LocalBusiness", query, got)
+		}
+	}
+}
+
+func TestToJSONLDFields(t *testing.T) {
+	landmarks := []Landmark{
+		{
+			Name:             "Fort",
+			PlaceID:          "id-Fort",
+			Address:          "Fort Road",
+			FormattedAddress: "Fort Road, Kanpur",
+			Location:         Location{Lat: 26.45, Lng: 80.33},
+			Rating:           4.5,
+			UserRatings:      120,
+		},
+		{Name: "New Cafe", Address: "Mall Road", Location: Location{Lat: 26.46, Lng: 80.34}},
+	}
+	data, err := json.Marshal(toJSONLD(landmarks))
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var things []map[string]interface{}
+	if err := json.Unmarshal(data, &things); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if len(things) != 2 {
+		t.Fatalf("got %d nodes, want 2", len(things))
+	}
+
+	fort := things[0]
+	if fort["@context"] != "https://schema.org" {
+		t.Errorf("@context = %v, want https://schema.org", fort["@context"])
+	}
+	if fort["name"] != "Fort" {
+		t.Errorf("name = %v, want Fort", fort["name"])
+	}
+	wantAddress := map[string]interface{}{"@type": "PostalAddress", "streetAddress": "Fort Road, Kanpur"}
+	if !reflect.DeepEqual(fort["address"], wantAddress) {
+		t.Errorf("address = %v, want %v", fort["address"], wantAddress)
+	}
+	wantGeo := map[string]interface{}{"@type": "GeoCoordinates", "latitude": 26.45, "longitude": 80.33}
+	if !reflect.DeepEqual(fort["geo"], wantGeo) {
+		t.Errorf("geo = %v, want %v", fort["geo"], wantGeo)
+	}
+	wantRating := map[string]interface{}{"@type": "AggregateRating", "ratingValue": 4.5, "reviewCount": float64(120), "bestRating": float64(5)}
+	if !reflect.DeepEqual(fort["aggregateRating"], wantRating) {
+		t.Errorf("aggregateRating = %v, want %v", fort["aggregateRating"], wantRating)
+	}
+
+	cafe := things[1]
+	if _, ok := cafe["aggregateRating"]; ok {
+		t.Errorf("aggregateRating = %v for an unrated place, want it omitted", cafe["aggregateRating"])
+	}
+	if address := cafe["address"].(map[string]interface{}); address["streetAddress"] != "Mall Road" {
+		t.Errorf("streetAddress = %v, want the vicinity when there's no formatted address", address["streetAddress"])
+	}
+}
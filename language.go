@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+)
+
+// fallbackLanguage is the last link of every language fallback chain
+const fallbackLanguage = "en"
+
+// mapsLanguages are the language codes Google Maps Platform supports
+var mapsLanguages = map[string]bool{
+	"af": true, "am": true, "ar": true, "az": true, "be": true, "bg": true, "bn": true, "bs": true,
+	"ca": true, "cs": true, "da": true, "de": true, "el": true, "en": true, "en-AU": true, "en-GB": true,
+	"es": true, "es-419": true, "et": true, "eu": true, "fa": true, "fi": true, "fil": true, "fr": true,
+	"fr-CA": true, "gl": true, "gu": true, "hi": true, "hr": true, "hu": true, "hy": true, "id": true,
+	"is": true, "it": true, "iw": true, "ja": true, "ka": true, "kk": true, "km": true, "kn": true,
+	"ko": true, "ky": true, "lo": true, "lt": true, "lv": true, "mk": true, "ml": true, "mn": true,
+	"mr": true, "ms": true, "my": true, "ne": true, "nl": true, "no": true, "pa": true, "pl": true,
+	"pt": true, "pt-BR": true, "pt-PT": true, "ro": true, "ru": true, "si": true, "sk": true, "sl": true,
+	"sq": true, "sr": true, "sv": true, "sw": true, "ta": true, "te": true, "th": true, "tr": true,
+	"uk": true, "ur": true, "uz": true, "vi": true, "zh": true, "zh-CN": true, "zh-HK": true,
+	"zh-TW": true, "zu": true,
+}
+
+// messageLanguages are the languages our own response messages are written in
+var messageLanguages = map[string]bool{"en": true}
+
+// resolveLanguage picks the language to use from a fallback chain: the requested language,
+// or its base language ("hi" for "hi-IN"), then Config.DefaultLanguage, then English. Codes
+// are matched case-insensitively.
+func (s *LocationService) resolveLanguage(requested string, supported map[string]bool) string {
+	for _, candidate := range []string{requested, baseLanguage(requested), s.config.DefaultLanguage} {
+		if code, ok := supportedLanguage(candidate, supported); ok {
+			return code
+		}
+	}
+	return fallbackLanguage
+}
+
+// baseLanguage strips the region from a language code, e.g. "pt-BR" to "pt"
+func baseLanguage(code string) string {
+	base, _, _ := strings.Cut(code, "-")
+	return base
+}
+
+// supportedLanguage returns the supported code matching code in any letter case
+func supportedLanguage(code string, supported map[string]bool) (string, bool) {
+	code = strings.TrimSpace(strings.ReplaceAll(code, "_", "-"))
+	if code == "" {
+		return "", false
+	}
+	for candidate := range supported {
+		if strings.EqualFold(candidate, code) {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// parseDefaultLanguage returns the configured default language if Google supports it,
+// otherwise English
+func parseDefaultLanguage(value string) string {
+	if value == "" {
+		return fallbackLanguage
+	}
+	code, ok := supportedLanguage(value, mapsLanguages)
+	if !ok {
+		log.Printf("Unsupported DEFAULT_LANGUAGE=%q, using %s", value, fallbackLanguage)
+		return fallbackLanguage
+	}
+	return code
+}
+
+// languageKey is the context key for a request's Maps result language
+type languageKey struct{}
+
+// withLanguage returns a context under which Maps calls request results in language, e.g.
+// "hi" or "ta". Carrying it in the context reaches every geocode, search and details call a
+// request makes without threading it through each helper.
+func withLanguage(ctx context.Context, language string) context.Context {
+	return context.WithValue(ctx, languageKey{}, language)
+}
+
+// languageFrom returns the context's Maps result language, defaulting to
+// Config.DefaultLanguage
+func (s *LocationService) languageFrom(ctx context.Context) string {
+	if language, ok := ctx.Value(languageKey{}).(string); ok {
+		return language
+	}
+	return s.config.DefaultLanguage
+}
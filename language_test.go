@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"googlemaps.github.io/maps"
+)
+
+func TestResolveLanguage(t *testing.T) {
+	service := newTestService(t, &fakeMapsClient{})
+	service.config.DefaultLanguage = "hi"
+
+	tests := []struct {
+		requested string
+		supported map[string]bool
+		want      string
+	}{
+		{"", mapsLanguages, "hi"},
+		{"ta", mapsLanguages, "ta"},
+		{"pt_br", mapsLanguages, "pt-BR"},
+		{"HI-IN", mapsLanguages, "hi"},
+		{"xx", mapsLanguages, "hi"},
+		{"ta", messageLanguages, "en"}, // neither Tamil nor the Hindi default has messages
+	}
+	for _, tt := range tests {
+		if got := service.resolveLanguage(tt.requested, tt.supported); got != tt.want {
+			t.Errorf("resolveLanguage(%q) = %q, want %q", tt.requested, got, tt.want)
+		}
+	}
+
+	if got := service.languageFrom(context.Background()); got != "hi" {
+		t.Errorf("languageFrom without a language = %q, want the default hi", got)
+	}
+	if got := service.languageFrom(withLanguage(context.Background(), "ta")); got != "ta" {
+		t.Errorf("languageFrom = %q, want ta", got)
+	}
+}
+
+func TestDefaultLanguagePerService(t *testing.T) {
+	// Each service uses its own configured default
+	for _, language := range []string{"ta", "en"} {
+		client := newAddressClient([]maps.PlacesSearchResult{fakePlace("Fort", 4.0, 100, 500)})
+		service := newTestService(t, client)
+		service.config.DefaultLanguage = language
+
+		response, err := service.GetNearbyLandmarks(context.Background(), GetLandmarksRequest{Address: "1 Mall Road"})
+		if err != nil {
+			t.Fatalf("GetNearbyLandmarks: %v", err)
+		}
+		if response.Language != language {
+			t.Errorf("response language = %q, want %q", response.Language, language)
+		}
+		if got := client.geocodeRequests[0].Language; got != language {
+			t.Errorf("geocode language = %q, want %q", got, language)
+		}
+		if got := client.nearbyRequests[0].Language; got != language {
+			t.Errorf("nearby search language = %q, want %q", got, language)
+		}
+	}
+}
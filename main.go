@@ -19,12 +19,30 @@ import (
 
 // Response structures
 type ValidationResponse struct {
-	Valid       bool     `json:"valid"`
-	Message     string   `json:"message"`
-	Suggestions []string `json:"suggestions,omitempty"`
-	Details     *Details `json:"details,omitempty"`
+	Valid         bool     `json:"valid"`
+	Message       string   `json:"message"`
+	FailureReason string   `json:"failure_reason,omitempty"`
+	Suggestions   []string `json:"suggestions,omitempty"`
+	Details       *Details `json:"details,omitempty"`
+	// GeocodeQueries are the geocode inputs sent to Google, only when DEBUG is set
+	GeocodeQueries []GeocodeQuery `json:"geocode_queries,omitempty"`
+	// APICalls counts the live Maps calls made, only with the X-Include-API-Calls header
+	APICalls *APICallCount `json:"api_calls,omitempty"`
+	// Language is the language Maps results were requested in, after fallback
+	Language string `json:"language,omitempty"`
+	// MessageLanguage is the language Message is written in, after fallback
+	MessageLanguage string `json:"message_language,omitempty"`
 }
 
+// Failure reasons reported in ValidationResponse.FailureReason
+const (
+	FailureEmptyInput    = "EMPTY_INPUT"    // PIN code or city missing
+	FailureInvalidFormat = "INVALID_FORMAT" // PIN code is malformed
+	FailurePinNotFound   = "PIN_NOT_FOUND"  // Geocoding found no location for the PIN
+	FailureCityMismatch  = "CITY_MISMATCH"  // PIN resolves to a different city
+	FailureLookupError   = "LOOKUP_ERROR"   // batch item not validated, e.g. a Maps error; may be retried
+)
+
 type Details struct {
 	PinCode          string `json:"pin_code"`
 	City             string `json:"city"`
@@ -38,18 +56,162 @@ type LandmarksResponse struct {
 	Message   string     `json:"message"`
 	Landmarks []Landmark `json:"landmarks"`
 	Location  Location   `json:"location"`
+	// NextCursor resumes after the last returned landmark; empty when there are no more
+	NextCursor string `json:"next_cursor,omitempty"`
+	// CenterConfidence is "high", "medium" or "low" depending on how precisely the input was geocoded
+	CenterConfidence string `json:"center_confidence,omitempty"`
+	// MatchedType is the place type that produced these results when a type fallback chain is used
+	MatchedType string `json:"matched_type,omitempty"`
+	// RawResultCount is how many places Google returned before any filtering
+	RawResultCount int `json:"raw_result_count"`
+	// TotalAvailable is how many landmarks survived filtering, across all pages
+	TotalAvailable int `json:"total_available"`
+	// RadiusUsed is the search radius in meters the results came from
+	RadiusUsed float64 `json:"radius_used,omitempty"`
+	// GeneratedAt is when the underlying data was fetched from Google, not when it was served
+	GeneratedAt time.Time `json:"generated_at"`
+	// CacheStatus is "hit", "miss" or "stale"
+	CacheStatus string `json:"cache_status"`
+	// Degraded is true when the landmarks are from an expired cache entry rather than live data
+	Degraded bool `json:"degraded,omitempty"`
+	// Origin is the search center's resolved address, only set when include_origin_details is requested
+	Origin *Details `json:"origin,omitempty"`
+	// Warnings are non-fatal problems with the request, e.g. an address and PIN code that disagree
+	Warnings []string `json:"warnings,omitempty"`
+	// Unit is the unit of each landmark's distance: "m", "km" or "mi"
+	Unit string `json:"unit,omitempty"`
+	// BoundingCircle encloses the search center and this page's landmarks, for setting a map zoom
+	BoundingCircle *BoundingCircle `json:"bounding_circle,omitempty"`
+	// CenterOffset is how far apart the address and PIN code resolved, in Unit; only set
+	// when both were given and cross-validated
+	CenterOffset *float64 `json:"center_offset,omitempty"`
+	// AvailableTypes are the place types across all results, not just this page, with counts
+	AvailableTypes []AvailableType `json:"available_types,omitempty"`
+	// Enrichments lists the per-landmark enrichments that ran for this page. A landmark
+	// missing an enrichment's field means the data was unavailable for it; enrichments not
+	// listed weren't requested.
+	Enrichments []string `json:"enrichments,omitempty"`
+	// PagesFetched is how many Nearby Search result pages the results were built from, for debugging
+	PagesFetched int `json:"pages_fetched,omitempty"`
+	// GeocodeQueries are the geocode inputs sent to Google for this request, only when DEBUG
+	// is set; empty when the results came from the result cache
+	GeocodeQueries []GeocodeQuery `json:"geocode_queries,omitempty"`
+	// APICalls counts the live Maps calls made, only with the X-Include-API-Calls header
+	APICalls *APICallCount `json:"api_calls,omitempty"`
+	// Language is the language Maps results were requested in, after fallback
+	Language string `json:"language,omitempty"`
+	// MessageLanguage is the language Message is written in, after fallback
+	MessageLanguage string `json:"message_language,omitempty"`
+}
+
+// Enrichment names reported in LandmarksResponse.Enrichments
+const (
+	EnrichmentDetails               = "details"                 // formatted_address, phone_number, website, opening_hours
+	EnrichmentReviewSampleBreakdown = "review_sample_breakdown" // review_sample_breakdown
+	EnrichmentOpen24Hours           = "open_24_hours"           // open_24_hours
+	EnrichmentRoadDistance          = "road_distance"           // road_distance
+	EnrichmentWalkability           = "walkability"             // walkability
+	EnrichmentWalkingTime           = "walking_time"            // walking_duration_seconds
+	EnrichmentTravel                = "travel"                  // travel_distance, travel_duration_seconds
+	EnrichmentPhotos                = "photos"                  // photos
+)
+
+// Cache statuses reported in LandmarksResponse.CacheStatus
+const (
+	CacheHit   = "hit"   // served from a fresh cached result
+	CacheMiss  = "miss"  // fetched live for this request
+	CacheStale = "stale" // served an expired cached result: the live fetch failed, or quiet hours
+)
+
+// searchCenter is the geocoded point a landmark search runs around
+type searchCenter struct {
+	Location   maps.LatLng
+	Address    string
+	Confidence string
+	// Name is the establishment the user searched for, if the input named one
+	Name string
+	// Details are the center's address components from the forward geocode, if known
+	Details *Details
+	// Warnings are non-fatal problems found while resolving the center
+	Warnings []string
+	// Offset is the meters between the address and PIN code results, if both were geocoded
+	Offset *float64
 }
 
+// Center confidence levels reported in LandmarksResponse.CenterConfidence
+const (
+	ConfidenceHigh   = "high"
+	ConfidenceMedium = "medium"
+	ConfidenceLow    = "low"
+)
+
 type Landmark struct {
-	Name        string   `json:"name"`
-	Address     string   `json:"address"`
-	Distance    float64  `json:"distance"`
-	PlaceID     string   `json:"place_id"`
+	Name string `json:"name"`
+	// Address is Google's short "vicinity" string, e.g. "MG Road, Kanpur"
+	Address string `json:"address"`
+	// FormattedAddress is the full postal address from Place Details, only set when details are requested
+	FormattedAddress string `json:"formatted_address,omitempty"`
+	// PhoneNumber (in local format), Website and OpeningHours (one line per weekday, e.g.
+	// "Monday: 9:00 AM – 9:00 PM") come from Place Details, only set when details are
+	// requested and Google has them
+	PhoneNumber  string   `json:"phone_number,omitempty"`
+	Website      string   `json:"website,omitempty"`
+	OpeningHours []string `json:"opening_hours,omitempty"`
+	Distance     float64  `json:"distance"`
+	// Bearing is the initial compass bearing from the search center in degrees (0 = north, 90 = east)
+	Bearing float64 `json:"bearing"`
+	// CompassDirection is Bearing as one of the 8 compass points, e.g. "NE"
+	CompassDirection string `json:"compass_direction"`
+	PlaceID          string `json:"place_id"`
+	// UID is a provider-independent identifier derived from the name and rounded coordinates
+	UID         string   `json:"uid"`
 	Types       []string `json:"types"`
 	Location    Location `json:"location"`
 	Rating      float32  `json:"rating"`
 	UserRatings int      `json:"user_ratings_total"`
 	PopScore    float64  `json:"popularity_score"`
+	// Rank is the 1-based position in the full ranked result set, not just this page
+	Rank int `json:"rank"`
+	// Enrichment holds extra fields added by the result webhook, if configured
+	Enrichment map[string]json.RawMessage `json:"enrichment,omitempty"`
+	// RoadDistance is the meters to the nearest road, only set when requested and a road was found
+	RoadDistance *float64 `json:"road_distance,omitempty"`
+	// Busyness is how crowded the place is right now, 0 (empty) to 1 (busiest), when the
+	// result webhook provides it; Google's APIs don't expose popular times
+	Busyness *float64 `json:"busyness,omitempty"`
+	// ReviewSampleBreakdown counts the reviews Place Details returned per star level ("1" to
+	// "5"), only set when requested with details. Google returns at most five reviews, so this
+	// is a sample, not the place's rating histogram; see reviewSampleBreakdown.
+	ReviewSampleBreakdown map[string]int `json:"review_sample_breakdown,omitempty"`
+	// OpenNow is Google's open-now flag from the nearby search, when it has hours for the place
+	OpenNow *bool `json:"open_now,omitempty"`
+	// Photos are /api/photo proxy URLs for up to three photos, only set when details or
+	// photos are requested
+	Photos []string `json:"photos,omitempty"`
+	// Open24Hours reports whether the place never closes, only set when open_24_hours is requested
+	Open24Hours *bool `json:"open_24_hours,omitempty"`
+	// Walkability rates how easy the place is to reach on foot, 0 to 1, only set when requested
+	Walkability *float64 `json:"walkability,omitempty"`
+	// WalkingDuration is the walking time from the search center in seconds, only set when
+	// walking times are requested and Google found a walking route
+	WalkingDuration *float64 `json:"walking_duration_seconds,omitempty"`
+	// TravelDistance is the route length from the search center in the response unit and
+	// TravelDuration the route time in seconds, by the requested travel mode; only set when
+	// travel_mode is given and Google found a route. Distance stays the straight line.
+	TravelDistance *float64 `json:"travel_distance,omitempty"`
+	TravelDuration *float64 `json:"travel_duration_seconds,omitempty"`
+
+	// allTypes keeps Google's full type list once Types is trimmed for display, so output
+	// derived from the types, such as JSON-LD, doesn't depend on display options
+	allTypes []string
+}
+
+// fullTypes returns the landmark's types before any trimming
+func (l Landmark) fullTypes() []string {
+	if l.allTypes != nil {
+		return l.allTypes
+	}
+	return l.Types
 }
 
 type Location struct {
@@ -60,6 +222,14 @@ type Location struct {
 type ValidatePinCodeRequest struct {
 	PinCode string `json:"pin_code"`
 	City    string `json:"city"`
+	// ResolveCity makes an empty city return the PIN code's city instead of failing
+	ResolveCity bool `json:"resolve_city,omitempty"`
+	// Language is the language for addresses and city names, e.g. "hi" (default "en")
+	Language string `json:"language,omitempty"`
+	// Country is the ISO code of the country the postal code belongs to, e.g. "GB". It picks
+	// the format check and restricts geocoding to that country (default
+	// Config.PostalCodeCountry, unrestricted).
+	Country string `json:"country,omitempty"`
 }
 
 type GetLandmarksRequest struct {
@@ -67,54 +237,187 @@ type GetLandmarksRequest struct {
 	City    string  `json:"city,omitempty"`
 	Address string  `json:"address,omitempty"` // New: Street address
 	Radius  float64 `json:"radius,omitempty"`  // in meters, default 1000
+	// Country is the ISO code of the country PinCode belongs to, e.g. "GB", as in
+	// ValidatePinCodeRequest.Country; it is not applied to Address
+	Country string `json:"country,omitempty"`
+	// IncludeDetails fetches Place Details for each returned landmark (one extra API call per landmark)
+	IncludeDetails bool `json:"include_details,omitempty"`
+	// Open24Hours keeps only places open around the clock, judged from the opening hours in
+	// Place Details; requires IncludeDetails and filters each page after it is cut
+	Open24Hours bool `json:"open_24_hours,omitempty"`
+	// IncludePhotos adds up to three photo URLs per landmark via Place Details; implied by
+	// IncludeDetails (one extra API call per landmark)
+	IncludePhotos bool `json:"include_photos,omitempty"`
+	// IncludeReviewSampleBreakdown adds star levels of the (at most five) reviews Google
+	// returns; requires IncludeDetails. Sent as include_rating_breakdown by older clients.
+	IncludeReviewSampleBreakdown bool `json:"include_review_sample_breakdown,omitempty"`
+	// ScoreWeights tunes the popularity score formula
+	ScoreWeights ScoreWeights `json:"score_weights,omitempty"`
+	// MinScore drops landmarks whose popularity score is below this value (0 = no filtering)
+	MinScore float64 `json:"min_score,omitempty"`
+	// TypesLimit keeps at most this many of each landmark's types, most specific first (0 = all)
+	TypesLimit int `json:"types_limit,omitempty"`
+	// SpecificTypesOnly drops generic types such as "establishment" from each landmark's types
+	SpecificTypesOnly bool `json:"specific_types_only,omitempty"`
+	// Language is the language for landmark names and addresses, e.g. "hi" (default "en")
+	Language string `json:"language,omitempty"`
+	// OpenNow asks Google for places open at search time only; places without hours data
+	// are then excluded by Google
+	OpenNow bool `json:"open_now,omitempty"`
+	// StrictRadius drops places Google returned beyond the requested radius
+	StrictRadius bool `json:"strict_radius,omitempty"`
+	// MinReviews drops rated places with fewer reviews than this (default 1, which keeps all
+	// rated places); places with no reviews are governed by IncludeUnrated instead
+	MinReviews int `json:"min_reviews,omitempty"`
+	// MinRating drops rated places whose Google rating is below this value (0 = no filtering)
+	MinRating float32 `json:"min_rating,omitempty"`
+	// Units is the unit for landmark distances in the response: "m" (default), "km" or "mi"
+	Units string `json:"units,omitempty"`
+	// Limit is the number of landmarks per page (default 5, capped at Config.MaxLandmarksLimit)
+	Limit int `json:"limit,omitempty"`
+	// Cursor is the next_cursor from a previous response, to fetch the following page
+	Cursor string `json:"cursor,omitempty"`
+	// NameFilter keeps only landmarks whose name contains this text (case-insensitive)
+	NameFilter string `json:"name_filter,omitempty"`
+	// IncludeUnrated keeps places with no reviews, scored at UnratedScore instead of being skipped
+	IncludeUnrated bool    `json:"include_unrated,omitempty"`
+	UnratedScore   float64 `json:"unrated_score,omitempty"`
+	// Types restricts results to places of any of these Google types, e.g. ["restaurant", "hospital"];
+	// unknown types are ignored with a note in the message
+	Types []string `json:"types,omitempty"`
+	// TypeFallbackChain lists place types to try in order until one yields at least
+	// MinFallbackResults landmarks (default 1)
+	TypeFallbackChain  []string `json:"type_fallback_chain,omitempty"`
+	MinFallbackResults int      `json:"min_fallback_results,omitempty"`
+	// AutoRadius probes several radii and picks the smallest yielding AutoRadiusMinResults
+	// landmarks (default 3); Radius is ignored when set
+	AutoRadius           bool `json:"auto_radius,omitempty"`
+	AutoRadiusMinResults int  `json:"auto_radius_min_results,omitempty"`
+	// MinResults doubles the radius, up to Config.MaxSearchRadius, until at least this many
+	// landmarks are found; ignored with AutoRadius
+	MinResults int `json:"min_results,omitempty"`
+	// IncludeRoadDistance looks up the distance to the nearest road (one extra API call per page)
+	IncludeRoadDistance bool `json:"include_road_distance,omitempty"`
+	// CategoryPriority multiplies a landmark's score by the value for its primary category
+	// (e.g. {"restaurant": 1.5, "atm": 0.5}); unlisted categories keep a multiplier of 1
+	CategoryPriority map[string]float64 `json:"category_priority,omitempty"`
+	// Keyword is matched by Google against place names, types and reviews; known terms are
+	// expanded with synonyms (e.g. "medicine" also searches "pharmacy" and "chemist")
+	Keyword string `json:"keyword,omitempty"`
+	// CheckOnLand reverse-geocodes the center first and stops if it has no address,
+	// e.g. coordinates in the sea (one extra Geocoding call)
+	CheckOnLand bool `json:"check_on_land,omitempty"`
+	// RequirePhotos drops landmarks that have no photos
+	RequirePhotos bool `json:"require_photos,omitempty"`
+	// IncludeOriginDetails adds the center's city, state and country to the response
+	// (one extra Geocoding call when the center's address isn't already known)
+	IncludeOriginDetails bool `json:"include_origin_details,omitempty"`
+	// PreferQuiet orders each page by busyness, quietest first, when busyness is known
+	PreferQuiet bool `json:"prefer_quiet,omitempty"`
+	// IncludeWalkability adds a walkability score per landmark, from straight-line distance
+	// alone unless IncludeWalkingTime also fetches walking routes (one extra call per page)
+	IncludeWalkability bool `json:"include_walkability,omitempty"`
+	IncludeWalkingTime bool `json:"include_walking_time,omitempty"`
+	// TravelMode adds the route distance and time to each landmark: "driving", "walking",
+	// "bicycling" or "transit" (one Distance Matrix element per landmark)
+	TravelMode string `json:"travel_mode,omitempty"`
+	// SortBy orders the results before they are paged: "popularity" (default), "distance"
+	// (nearest first), "rating" or "reviews" (highest first). "travel_duration" needs
+	// TravelMode and, since routes are fetched per page, re-sorts each popularity-ranked page.
+	SortBy string `json:"sort_by,omitempty"`
 }
 
+// maxTypeFallbackChain caps how many place types one request may try
+const maxTypeFallbackChain = 5
+
+// maxNameFilterLength caps the length of GetLandmarksRequest.NameFilter in characters
+const maxNameFilterLength = 100
+
 // Service structure
 type LocationService struct {
-	mapsClient *maps.Client
+	mapsClient   MapsClient
+	httpClient   *http.Client
+	config       Config
+	resultCache  *ttlCache[*scoredSet]
+	geocodeCache *ttlCache[[]maps.GeocodingResult]
+	photoCache   *ttlCache[*photoData]
+	// placeProviders are the nearby search sources, in Config.NearbyProviders order
+	placeProviders []placeProvider
+	readiness      readinessCheck
+	// metrics is nil unless Config.MetricsEnabled
+	metrics *serviceMetrics
 }
 
 // NewLocationService creates a new location service instance
-func NewLocationService(apiKey string) (*LocationService, error) {
+func NewLocationService(apiKey string, config Config) (*LocationService, error) {
 	client, err := maps.NewClient(maps.WithAPIKey(apiKey))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create maps client: %v", err)
 	}
-	return &LocationService{mapsClient: client}, nil
+	return newLocationService(client, config), nil
 }
 
-// ValidatePinCodeWithCity validates if the PIN code matches the city
-func (s *LocationService) ValidatePinCodeWithCity(ctx context.Context, pinCode, city string) (*ValidationResponse, error) {
+// newLocationService creates a service around any MapsClient, such as a fake in tests
+func newLocationService(client MapsClient, config Config) *LocationService {
+	var metrics *serviceMetrics
+	if config.MetricsEnabled {
+		metrics = newServiceMetrics()
+		client = instrumentedClient{client, metrics}
+	}
+	service := &LocationService{
+		mapsClient:   countingClient{client},
+		httpClient:   &http.Client{},
+		config:       config,
+		resultCache:  newStaleTTLCache[*scoredSet](config.ResultCacheTTL, config.ResultCacheStaleTTL, config.ResultCacheMaxEntries),
+		geocodeCache: newTTLCache[[]maps.GeocodingResult](config.GeocodeCacheTTL, config.GeocodeCacheMaxEntries),
+		photoCache:   newTTLCache[*photoData](photoCacheTTL, photoCacheMaxEntries),
+		metrics:      metrics,
+	}
+	service.resultCache.sizer = jsonSize[*scoredSet]
+	service.geocodeCache.sizer = jsonSize[[]maps.GeocodingResult]
+	service.photoCache.sizer = func(photo *photoData) int { return len(photo.Data) }
+	service.resultCache.ttlFactor = service.cacheTTLFactor
+	service.geocodeCache.ttlFactor = service.cacheTTLFactor
+	service.placeProviders = newPlaceProviders(service, config.NearbyProviders)
+	return service
+}
+
+// ValidatePinCodeWithCity validates if the PIN code matches the city. A non-empty country
+// restricts the lookup to that country, see ValidatePinCodeRequest.Country.
+func (s *LocationService) ValidatePinCodeWithCity(ctx context.Context, pinCode, city, country string) (*ValidationResponse, error) {
 	// Clean inputs
 	pinCode = strings.TrimSpace(pinCode)
 	city = strings.ToLower(strings.TrimSpace(city))
+	country = normalizeCountry(country)
 
 	if pinCode == "" || city == "" {
 		return &ValidationResponse{
-			Valid:   false,
-			Message: "PIN code and city are required",
+			Valid:         false,
+			Message:       "PIN code and city are required",
+			FailureReason: FailureEmptyInput,
 		}, nil
 	}
 
-	// Geocode the PIN code to get location details
-	geocodeReq := &maps.GeocodingRequest{
-		Address: pinCode,
-		Components: map[maps.Component]string{
-			maps.ComponentPostalCode: pinCode,
-		},
+	if failure := s.checkPinFormat(pinCode, country); failure != nil {
+		return failure, nil
 	}
 
-	results, err := s.mapsClient.Geocode(ctx, geocodeReq)
+	// Geocode the PIN code to get location details
+	results, err := s.geocodePinCode(ctx, pinCode, country)
 	if err != nil {
-		return nil, fmt.Errorf("geocoding failed: %v", err)
+		return nil, err
 	}
 
 	if len(results) == 0 {
 		return &ValidationResponse{
-			Valid:   false,
-			Message: "Invalid PIN code: No location found",
+			Valid:         false,
+			Message:       "Invalid PIN code: No location found",
+			FailureReason: FailurePinNotFound,
 		}, nil
 	}
+	if err := s.checkServiceCountry(results[0]); err != nil {
+		return nil, err
+	}
 
 	// Extract city from the geocoding results
 	var foundCity, foundState, foundCountry string
@@ -137,8 +440,8 @@ func (s *LocationService) ValidatePinCodeWithCity(ctx context.Context, pinCode,
 			}
 		}
 
-		// Check if the provided city matches
-		if strings.Contains(foundCity, city) || strings.Contains(city, foundCity) {
+		// Check if the provided city matches, allowing for renamed cities
+		if s.citiesMatch(foundCity, city) {
 			return &ValidationResponse{
 				Valid:   true,
 				Message: "PIN code and city match successfully",
@@ -160,9 +463,10 @@ func (s *LocationService) ValidatePinCodeWithCity(ctx context.Context, pinCode,
 	}
 
 	return &ValidationResponse{
-		Valid:       false,
-		Message:     fmt.Sprintf("PIN code %s does not belong to %s", pinCode, city),
-		Suggestions: suggestions,
+		Valid:         false,
+		Message:       fmt.Sprintf("PIN code %s does not belong to %s", pinCode, city),
+		FailureReason: FailureCityMismatch,
+		Suggestions:   s.limitSuggestions(suggestions),
 		Details: &Details{
 			PinCode:          pinCode,
 			City:             foundCity,
@@ -173,91 +477,607 @@ func (s *LocationService) ValidatePinCodeWithCity(ctx context.Context, pinCode,
 	}, nil
 }
 
+// ResolvePinCode looks up the city, state and country of a PIN code without checking it
+// against a city. A non-empty country restricts the lookup like in ValidatePinCodeWithCity.
+func (s *LocationService) ResolvePinCode(ctx context.Context, pinCode, country string) (*ValidationResponse, error) {
+	pinCode = strings.TrimSpace(pinCode)
+	country = normalizeCountry(country)
+	if pinCode == "" {
+		return &ValidationResponse{
+			Valid:         false,
+			Message:       "PIN code is required",
+			FailureReason: FailureEmptyInput,
+		}, nil
+	}
+	if failure := s.checkPinFormat(pinCode, country); failure != nil {
+		return failure, nil
+	}
+
+	results, err := s.geocodePinCode(ctx, pinCode, country)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return &ValidationResponse{
+			Valid:         false,
+			Message:       "Invalid PIN code: No location found",
+			FailureReason: FailurePinNotFound,
+		}, nil
+	}
+	if err := s.checkServiceCountry(results[0]); err != nil {
+		return nil, err
+	}
+
+	details := detailsFromResult(results[0])
+	details.PinCode = pinCode
+	return &ValidationResponse{
+		Valid:   true,
+		Message: fmt.Sprintf("PIN code %s is in %s", pinCode, details.City),
+		Details: details,
+	}, nil
+}
+
+// geocodePinCode geocodes a PIN code. Config.PostalComponentMode decides whether results
+// are restricted to postal-code matches: always, only when that finds something, or never.
+// A non-empty country is always added as a country component, in every mode.
+func (s *LocationService) geocodePinCode(ctx context.Context, pinCode, country string) ([]maps.GeocodingResult, error) {
+	geocodeReq := &maps.GeocodingRequest{Address: pinCode, Components: map[maps.Component]string{}}
+	if country != "" {
+		geocodeReq.Components[maps.ComponentCountry] = country
+	}
+	if s.config.PostalComponentMode != PostalComponentOmit {
+		geocodeReq.Components[maps.ComponentPostalCode] = pinCode
+	}
+
+	results, err := s.geocode(ctx, geocodeReq)
+	if err == nil && len(results) == 0 && s.config.PostalComponentMode == PostalComponentLoose {
+		delete(geocodeReq.Components, maps.ComponentPostalCode)
+		results, err = s.geocode(ctx, geocodeReq)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("geocoding failed: %w", checkAPIEnabled(geocodingAPI, err))
+	}
+	return results, nil
+}
+
+// scoredSet is the full ranked result of a nearby search, cached per request fingerprint
+type scoredSet struct {
+	Landmarks       []Landmark
+	Location        maps.LatLng
+	LocationAddress string
+	Confidence      string
+	MatchedType     string
+	RadiusUsed      float64
+	RawResultCount  int
+	BelowMinScore   int
+	BelowMinRating  int
+	FewReviews      int
+	OutsideRadius   int
+	NameFiltered    int
+	OriginExcluded  int
+	UnratedSkipped  int
+	WithoutPhotos   int
+	TypeFiltered    int
+	Not24Hours      int
+	HoursUnknown    int
+	HoursUnchecked  int
+	GeneratedAt     time.Time
+	Origin          *Details
+	Warnings        []string
+	CenterOffset    *float64
+	PagesFetched    int
+}
+
+// landmarksPageSize is the default number of landmarks returned per page
+const landmarksPageSize = 5
+
+// pageSize returns how many landmarks a page holds for the requested limit: the default
+// when unset, capped at Config.MaxLandmarksLimit
+func (s *LocationService) pageSize(limit int) int {
+	if limit <= 0 {
+		return landmarksPageSize
+	}
+	return min(limit, s.config.MaxLandmarksLimit)
+}
+
+// limitSuggestions removes duplicate suggestions and keeps at most Config.MaxSuggestions.
+// Suggestions are built in Google's result order, best match first, so truncation keeps
+// the most relevant ones.
+func (s *LocationService) limitSuggestions(suggestions []string) []string {
+	seen := make(map[string]bool, len(suggestions))
+	limited := make([]string, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		key := strings.ToLower(suggestion)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		limited = append(limited, suggestion)
+		if len(limited) == s.config.MaxSuggestions {
+			break
+		}
+	}
+	return limited
+}
+
 // GetNearbyLandmarks fetches nearby landmarks for a given location
 // Supports both PIN code + city and street address inputs
-func (s *LocationService) GetNearbyLandmarks(ctx context.Context, pinCode, city, address string, radius float64) (*LandmarksResponse, error) {
-	var location maps.LatLng
-	var locationAddress string
+func (s *LocationService) GetNearbyLandmarks(ctx context.Context, req GetLandmarksRequest) (*LandmarksResponse, error) {
+	// Resolve the language first so equivalent codes ("HI", "hi-IN") share a cache entry
+	requested := req.Language
+	req.Language = s.resolveLanguage(requested, mapsLanguages)
+
+	response, err := s.nearbyLandmarks(withLanguage(ctx, req.Language), req)
+	if response != nil {
+		response.Language = req.Language
+		response.MessageLanguage = s.resolveLanguage(requested, messageLanguages)
+	}
+	return response, err
+}
 
-	// Determine which input method to use
-	if address != "" {
-		// Use street address for geocoding
-		locationAddress = strings.TrimSpace(address)
-		geocodeReq := &maps.GeocodingRequest{
-			Address: locationAddress,
+// searchPlan is a landmark request checked and normalized for searching
+type searchPlan struct {
+	// req has its radius clamped, sort order parsed and unknown types dropped
+	req           GetLandmarksRequest
+	radiusClamped bool
+	cursor        pageCursor
+	unit          string
+	travelMode    maps.Mode
+	ignoredTypes  []string
+	// scoreDecimals is Config.ScoreDecimals, applied to PopScore by display
+	scoreDecimals int
+}
+
+// planSearch validates a landmark request and normalizes it into a searchPlan. Every way
+// of running a search goes through it, so limits such as Config.MaxSearchRadius apply
+// everywhere and equivalent requests share a cache entry. A non-nil LandmarksResponse
+// reports an invalid request.
+func (s *LocationService) planSearch(req GetLandmarksRequest) (*searchPlan, *LandmarksResponse) {
+	if req.Radius < 0 {
+		return nil, &LandmarksResponse{
+			Success: false,
+			Message: "Invalid radius: must not be negative",
 		}
+	}
+	plan := &searchPlan{}
+	// Clamp before anything keys off the request, so clamped and explicit requests share a cache entry
+	plan.radiusClamped = req.Radius > s.config.MaxSearchRadius
+	if plan.radiusClamped {
+		req.Radius = s.config.MaxSearchRadius
+	}
 
-		geocodeResults, err := s.mapsClient.Geocode(ctx, geocodeReq)
+	// Resolve where to resume from before doing any API work
+	if req.Cursor != "" {
+		var err error
+		plan.cursor, err = decodeCursor(req.Cursor)
 		if err != nil {
-			return nil, fmt.Errorf("geocoding address failed: %v", err)
+			return nil, &LandmarksResponse{
+				Success: false,
+				Message: "Invalid cursor. Restart the search without a cursor.",
+			}
 		}
+	}
 
-		if len(geocodeResults) == 0 {
-			return &LandmarksResponse{
-				Success: false,
-				Message: "Could not find the specified address",
-			}, nil
+	if req.Open24Hours && !req.IncludeDetails {
+		return nil, &LandmarksResponse{
+			Success: false,
+			Message: "open_24_hours needs opening hours from Place Details; set include_details as well",
 		}
+	}
 
-		location = geocodeResults[0].Geometry.Location
-		locationAddress = geocodeResults[0].FormattedAddress
-	} else if pinCode != "" && city != "" {
-		// Use PIN code + city method (original logic)
-		validation, err := s.ValidatePinCodeWithCity(ctx, pinCode, city)
-		if err != nil {
-			return nil, err
+	var ok bool
+	plan.unit, ok = parseUnit(req.Units)
+	if !ok {
+		return nil, &LandmarksResponse{
+			Success: false,
+			Message: fmt.Sprintf("Invalid units %q: use m, km or mi", req.Units),
 		}
+	}
 
-		if !validation.Valid {
-			return &LandmarksResponse{
-				Success: false,
-				Message: validation.Message,
-			}, nil
+	plan.travelMode, ok = parseTravelMode(req.TravelMode)
+	if !ok {
+		return nil, &LandmarksResponse{
+			Success: false,
+			Message: fmt.Sprintf("Invalid travel_mode %q: use driving, walking, bicycling or transit", req.TravelMode),
 		}
+	}
 
-		// Geocode to get exact coordinates
-		geocodeReq := &maps.GeocodingRequest{
-			Address: fmt.Sprintf("%s, %s", pinCode, city),
+	sortBy, ok := parseSortBy(req.SortBy)
+	if !ok {
+		return nil, &LandmarksResponse{
+			Success: false,
+			Message: fmt.Sprintf("Invalid sort_by %q: use popularity, distance, rating, reviews or travel_duration", req.SortBy),
 		}
+	}
+	req.SortBy = sortBy
+	if sortBy == SortTravelDuration && plan.travelMode == "" {
+		return nil, &LandmarksResponse{
+			Success: false,
+			Message: "sort_by travel_duration needs route times; set travel_mode as well",
+		}
+	}
 
-		geocodeResults, err := s.mapsClient.Geocode(ctx, geocodeReq)
-		if err != nil {
-			return nil, fmt.Errorf("geocoding failed: %v", err)
+	// Drop place types Google doesn't know rather than failing the search
+	if len(req.Types) > 0 {
+		req.Types, plan.ignoredTypes = validPlaceTypes(req.Types)
+	}
+
+	plan.req = req
+	plan.scoreDecimals = s.config.ScoreDecimals
+	return plan, nil
+}
+
+// display converts a landmark's distances to the plan's unit, rounds its score and trims
+// its types as requested, the last step before a landmark is returned. Sorting and cursors
+// use the full-precision score, so sub-decimal ties still rank correctly.
+func (p *searchPlan) display(landmark Landmark) Landmark {
+	landmark.PopScore = roundTo(landmark.PopScore, p.scoreDecimals)
+	landmark.Distance = convertDistance(landmark.Distance, p.unit)
+	if travel := landmark.TravelDistance; travel != nil {
+		converted := convertDistance(*travel, p.unit)
+		landmark.TravelDistance = &converted
+	}
+	landmark.allTypes = landmark.fullTypes()
+	landmark.Types = trimTypes(landmark.Types, p.req.TypesLimit, p.req.SpecificTypesOnly)
+	return landmark
+}
+
+// nearbyLandmarks does the work of GetNearbyLandmarks once the language is resolved
+func (s *LocationService) nearbyLandmarks(ctx context.Context, req GetLandmarksRequest) (*LandmarksResponse, error) {
+	plan, failure := s.planSearch(req)
+	if failure != nil {
+		return failure, nil
+	}
+	req = plan.req
+	unit, travelMode, sortBy := plan.unit, plan.travelMode, req.SortBy
+
+	set, cacheStatus, failure, err := s.cachedSearch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if failure != nil {
+		return failure, nil
+	}
+
+	start := 0
+	if req.Cursor != "" {
+		start = cursorOffset(set.Landmarks, plan.cursor, ranksByScore(req.SortBy))
+	}
+
+	// Select the next page of landmarks
+	end := start + s.pageSize(req.Limit)
+	if end > len(set.Landmarks) {
+		end = len(set.Landmarks)
+	}
+	landmarks := append([]Landmark{}, set.Landmarks[start:end]...)
+
+	var nextCursor string
+	if end < len(set.Landmarks) && len(landmarks) > 0 {
+		nextCursor = encodeCursor(landmarks[len(landmarks)-1])
+	}
+
+	// Fetch full details only for the landmarks we return. Each enrichment that runs is
+	// listed in the response, so a missing field can be told apart from one not requested.
+	var enrichments []string
+	var details detailsOptions
+	if req.IncludeDetails {
+		enrichments = append(enrichments, EnrichmentDetails)
+		details.Details = true
+		if req.IncludeReviewSampleBreakdown {
+			enrichments = append(enrichments, EnrichmentReviewSampleBreakdown)
+			details.ReviewSampleBreakdown = true
+		}
+		if req.Open24Hours {
+			// The set was filtered before paging; this only lists the enrichment
+			enrichments = append(enrichments, EnrichmentOpen24Hours)
+		}
+	}
+	if req.IncludeDetails || req.IncludePhotos {
+		enrichments = append(enrichments, EnrichmentPhotos)
+		details.Photos = true
+	}
+	s.enrichWithDetails(ctx, landmarks, details)
+	if req.IncludeRoadDistance {
+		enrichments = append(enrichments, EnrichmentRoadDistance)
+		s.enrichWithRoadDistance(ctx, landmarks)
+	}
+	// Walking travel routes double as walking times, saving a second Distance Matrix call
+	var walkingRoutes []maps.DistanceMatrixElement
+	if travelMode != "" {
+		enrichments = append(enrichments, EnrichmentTravel)
+		routes := s.enrichWithTravel(ctx, set.Location, landmarks, travelMode)
+		if travelMode == maps.TravelModeWalking {
+			walkingRoutes = routes
+		}
+	}
+	if req.IncludeWalkability {
+		enrichments = append(enrichments, EnrichmentWalkability)
+		if req.IncludeWalkingTime {
+			enrichments = append(enrichments, EnrichmentWalkingTime)
 		}
+		s.enrichWithWalkability(ctx, set.Location, landmarks, req.IncludeWalkingTime, walkingRoutes)
+	}
 
-		if len(geocodeResults) == 0 {
-			return &LandmarksResponse{
-				Success: false,
-				Message: "Could not find location coordinates",
-			}, nil
+	// Let the optional result webhook enrich the final list
+	landmarks = s.transformLandmarks(ctx, landmarks)
+	if req.PreferQuiet {
+		sortQuietFirst(landmarks)
+	}
+	if sortBy == SortTravelDuration {
+		sortByTravelDuration(landmarks)
+	}
+
+	// Convert distances for display only after everything that works in meters, and trim
+	// types last so the webhook still sees them all
+	for i := range landmarks {
+		landmarks[i] = plan.display(landmarks[i])
+	}
+
+	// Only report the matched type when the caller asked for a fallback chain
+	var matchedType string
+	if len(req.TypeFallbackChain) > 0 {
+		matchedType = set.MatchedType
+	}
+
+	message := fmt.Sprintf("Found %d landmarks near %s", len(landmarks), set.LocationAddress)
+	if len(landmarks) == 0 {
+		if req.Cursor != "" {
+			message = "No more landmarks"
+		} else {
+			message = emptyResultMessage(set, req)
 		}
+	}
+	if plan.radiusClamped {
+		message += fmt.Sprintf(" (radius clamped to the maximum of %.0fm)", s.config.MaxSearchRadius)
+	}
+	if req.Open24Hours {
+		message += " (only places open 24 hours)"
+		if set.HoursUnchecked > 0 && len(landmarks) > 0 {
+			message += fmt.Sprintf(" (only the first %d places were checked for opening hours)", maxOpen24HoursLookups)
+		}
+		if set.HoursUnknown > 0 && len(landmarks) > 0 {
+			message += fmt.Sprintf(" (opening hours of %d places couldn't be looked up; try again)", set.HoursUnknown)
+		}
+	}
+	if len(plan.ignoredTypes) > 0 {
+		message += fmt.Sprintf(" (ignored unknown types: %s)", strings.Join(plan.ignoredTypes, ", "))
+	}
 
-		location = geocodeResults[0].Geometry.Location
-		locationAddress = geocodeResults[0].FormattedAddress
-	} else {
-		return &LandmarksResponse{
+	var centerOffset *float64
+	if set.CenterOffset != nil {
+		offset := convertDistance(*set.CenterOffset, unit)
+		centerOffset = &offset
+	}
+
+	origin := Location{Lat: set.Location.Lat, Lng: set.Location.Lng}
+	return &LandmarksResponse{
+		Success:          true,
+		Message:          message,
+		Landmarks:        landmarks,
+		NextCursor:       nextCursor,
+		CenterConfidence: set.Confidence,
+		Origin:           set.Origin,
+		Warnings:         s.withDominanceWarning(set.Warnings, req, landmarks),
+		MatchedType:      matchedType,
+		RawResultCount:   set.RawResultCount,
+		TotalAvailable:   len(set.Landmarks),
+		RadiusUsed:       set.RadiusUsed,
+		GeneratedAt:      set.GeneratedAt,
+		CacheStatus:      cacheStatus,
+		Degraded:         cacheStatus == CacheStale,
+		Unit:             unit,
+		BoundingCircle:   boundingCircle(origin, landmarks),
+		CenterOffset:     centerOffset,
+		AvailableTypes:   availableTypes(set.Landmarks),
+		Enrichments:      enrichments,
+		PagesFetched:     set.PagesFetched,
+		Location:         origin,
+	}, nil
+}
+
+// cachedSearch normalizes the request and returns its full scored set, reusing the
+// cached set while it's fresh so cursors stay stable across pages. The returned cache
+// status is CacheHit, CacheMiss or CacheStale.
+func (s *LocationService) cachedSearch(ctx context.Context, req GetLandmarksRequest) (*scoredSet, string, *LandmarksResponse, error) {
+	// Trim and bound the name filter
+	req.NameFilter = strings.TrimSpace(req.NameFilter)
+	if runes := []rune(req.NameFilter); len(runes) > maxNameFilterLength {
+		req.NameFilter = string(runes[:maxNameFilterLength])
+	}
+
+	// Bound the type lists to protect quota
+	if len(req.Types) > maxTypes {
+		return nil, "", &LandmarksResponse{
 			Success: false,
-			Message: "Please provide either an address OR both pin code and city",
+			Message: fmt.Sprintf("types can have at most %d entries", maxTypes),
+		}, nil
+	}
+	if len(req.TypeFallbackChain) > maxTypeFallbackChain {
+		return nil, "", &LandmarksResponse{
+			Success: false,
+			Message: fmt.Sprintf("type_fallback_chain can have at most %d types", maxTypeFallbackChain),
 		}, nil
 	}
 
+	key := req.fingerprint()
+	if set, ok := s.resultCache.Get(key); ok {
+		return set, CacheHit, nil, nil
+	}
+	// During quiet hours an expired result beats spending quota on a live search
+	if s.inQuietHours() {
+		if stale, ok := s.resultCache.GetStale(key); ok {
+			return stale, CacheStale, nil, nil
+		}
+	}
+
+	set, failure, err := s.searchLandmarks(ctx, req)
+	if err != nil {
+		// Prefer a recently expired result over failing outright
+		if stale, ok := s.resultCache.GetStale(key); ok {
+			log.Printf("Serving stale landmarks after search error: %v", err)
+			return stale, CacheStale, nil, nil
+		}
+		return nil, "", nil, err
+	}
+	if failure != nil {
+		return nil, "", failure, nil
+	}
+	// A set missing landmarks only because a lookup failed would stay wrong until it expires
+	if set.HoursUnknown == 0 {
+		s.resultCache.Set(key, set)
+	}
+	return set, CacheMiss, nil, nil
+}
+
+// searchLandmarks resolves the search center, runs the nearby search and returns
+// every qualifying landmark ranked by popularity. A non-nil LandmarksResponse
+// reports a user-facing failure (e.g. address not found).
+func (s *LocationService) searchLandmarks(ctx context.Context, req GetLandmarksRequest) (*scoredSet, *LandmarksResponse, error) {
+	radius := req.Radius
+
+	center, failure, err := s.resolveSearchCenter(ctx, req)
+	if err != nil || failure != nil {
+		return nil, failure, err
+	}
+	location := center.Location
+
+	// Don't spend a nearby search on a center nobody lives at
+	var reverse []maps.GeocodingResult
+	if req.CheckOnLand {
+		reverse, err = s.reverseGeocode(ctx, location)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !hasAddress(reverse) {
+			return nil, &LandmarksResponse{
+				Success: false,
+				Message: "The location appears to be in an unpopulated or water area. Check the address or coordinates.",
+			}, nil
+		}
+	}
+
 	// Default radius
 	if radius == 0 {
 		radius = 1000 // 1km default
 	}
 
-	// Search for nearby landmarks
-	nearbyReq := &maps.NearbySearchRequest{
-		Location: &location,
-		Radius:   uint(radius),
-		Type:     maps.PlaceType("point_of_interest"),
+	// Try each place type in order until one yields enough landmarks. Each step may
+	// search several types at once, merging their results.
+	var steps [][]string
+	for _, placeType := range req.TypeFallbackChain {
+		steps = append(steps, []string{placeType})
+	}
+	if len(steps) == 0 && len(req.Types) > 0 {
+		steps = [][]string{req.Types}
+	}
+	if len(steps) == 0 {
+		steps = [][]string{s.config.DefaultSearchTypes}
+		if req.Keyword != "" {
+			// Let the keyword alone decide what kind of place matches
+			steps = [][]string{{""}}
+		}
+	}
+	minResults := req.MinFallbackResults
+	if minResults <= 0 {
+		minResults = 1
 	}
 
-	nearbyResults, err := s.mapsClient.NearbySearch(ctx, nearbyReq)
-	if err != nil {
-		return nil, fmt.Errorf("nearby search failed: %v", err)
+	var best *scoredSet
+	for _, placeTypes := range steps {
+		var set *scoredSet
+		var err error
+		switch {
+		case req.AutoRadius:
+			set, err = s.probeRadii(ctx, center, placeTypes, req)
+		case req.MinResults > 0:
+			set, err = s.expandRadius(ctx, center, radius, placeTypes, req)
+		default:
+			set, err = s.nearbyScored(ctx, center, radius, placeTypes, req)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		set.MatchedType = strings.Join(placeTypes, ",")
+		if best == nil || len(set.Landmarks) > len(best.Landmarks) {
+			best = set
+		}
+		if len(set.Landmarks) >= minResults {
+			best = set
+			break
+		}
 	}
 
+	// Filter the whole set rather than each page, so pages stay full and ranks contiguous
+	if req.Open24Hours {
+		s.filterOpen24Hours(ctx, best)
+	}
+
+	best.Location = location
+	best.GeneratedAt = time.Now()
+	best.LocationAddress = center.Address
+	best.Confidence = center.Confidence
+	best.Warnings = center.Warnings
+	best.CenterOffset = center.Offset
+	if req.IncludeOriginDetails {
+		best.Origin, err = s.originDetails(ctx, center, reverse)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return best, nil, nil
+}
+
+// nearbyScored runs a nearby search of the given radius for each place type and scores
+// the merged results
+func (s *LocationService) nearbyScored(ctx context.Context, center *searchCenter, radius float64, placeTypes []string, req GetLandmarksRequest) (*scoredSet, error) {
+	// A keyword with known synonyms fans out into one search per term
+	terms := []string{""}
+	if req.Keyword != "" {
+		terms = s.expandKeyword(req.Keyword)
+	}
+
+	var places []maps.PlacesSearchResult
+	pagesFetched := 0
+	seen := make(map[string]bool)
+	for _, placeType := range placeTypes {
+		for _, term := range terms {
+			// Search for nearby landmarks
+			nearbyReq := &maps.NearbySearchRequest{
+				Location: &center.Location,
+				Radius:   uint(radius),
+				Keyword:  term,
+				Type:     maps.PlaceType(placeType),
+				OpenNow:  req.OpenNow,
+			}
+
+			results, fetched, err := s.searchNearby(ctx, nearbyReq)
+			pagesFetched += fetched
+			if err != nil {
+				return nil, err
+			}
+
+			// Merge by place ID so a place found under several types or terms is scored once
+			for _, place := range results {
+				if seen[place.PlaceID] {
+					continue
+				}
+				seen[place.PlaceID] = true
+				places = append(places, place)
+			}
+		}
+	}
+
+	set := s.scorePlaces(center, places, radius, req)
+	set.RadiusUsed = radius
+	set.PagesFetched = pagesFetched
+	return set, nil
+}
+
+// scorePlaces filters and scores raw nearby-search results from a search of the given
+// radius around the search center and returns them ranked by popularity
+func (s *LocationService) scorePlaces(center *searchCenter, places []maps.PlacesSearchResult, radius float64, req GetLandmarksRequest) *scoredSet {
+	location := center.Location
+	originName := normalizePlaceName(center.Name)
 	// Process all results and calculate scores
 	type scoredLandmark struct {
 		landmark Landmark
@@ -265,25 +1085,96 @@ func (s *LocationService) GetNearbyLandmarks(ctx context.Context, pinCode, city,
 	}
 
 	scoredLandmarks := []scoredLandmark{}
-
-	for _, place := range nearbyResults.Results {
+	belowMinScore := 0
+	belowMinRating := 0
+	fewReviews := 0
+	outsideRadius := 0
+	nameFiltered := 0
+	originExcluded := 0
+	unratedSkipped := 0
+	withoutPhotos := 0
+	typeFiltered := 0
+	nameFilter := strings.ToLower(req.NameFilter)
+
+	for _, place := range places {
 		// Calculate distance
 		distance := calculateDistance(
 			location.Lat, location.Lng,
 			place.Geometry.Location.Lat, place.Geometry.Location.Lng,
 		)
 
-		// Skip places that are too close (likely the same location) or have no reviews
-		if distance < 10 || place.UserRatingsTotal == 0 {
+		// Skip places that are too close (likely the same location)
+		if distance < 10 {
+			originExcluded++
+			continue
+		}
+
+		// Google's radius is a bias, not a bound; clip to it exactly when asked
+		if req.StrictRadius && distance > radius {
+			outsideRadius++
+			continue
+		}
+
+		// Skip the searched place itself when the user searched by its name
+		if originName != "" && nameSimilarity(originName, normalizePlaceName(place.Name)) >= s.config.OriginNameMatchThreshold {
+			originExcluded++
+			continue
+		}
+
+		// Unrated places are skipped unless the caller opts in
+		unrated := place.UserRatingsTotal == 0
+		if unrated && !req.IncludeUnrated {
+			unratedSkipped++
+			continue
+		}
+
+		// Only rated places are held to the review minimum; unrated ones were handled above
+		if !unrated && place.UserRatingsTotal < req.MinReviews {
+			fewReviews++
+			continue
+		}
+
+		// Unrated places kept by include_unrated have no rating to compare
+		if !unrated && place.Rating < req.MinRating {
+			belowMinRating++
+			continue
+		}
+
+		// Photo references come with the nearby search results at no extra cost
+		if req.RequirePhotos && len(place.Photos) == 0 {
+			withoutPhotos++
+			continue
+		}
+
+		// Google treats the search type loosely; keep only places of a requested type
+		if len(req.Types) > 0 && !hasAnyType(place.Types, req.Types) {
+			typeFiltered++
+			continue
+		}
+
+		// Apply the local name filter to the fetched results
+		if nameFilter != "" && !strings.Contains(strings.ToLower(place.Name), nameFilter) {
+			nameFiltered++
 			continue
 		}
 
-		// Calculate popularity score
-		// Formula: (rating * log10(reviews + 1)) / (1 + distance/1000)
-		// This balances rating, number of reviews, and distance
-		reviewScore := float64(place.Rating) * math.Log10(float64(place.UserRatingsTotal)+1)
-		distancePenalty := 1.0 + (distance / 1000.0) // Penalty increases with distance
-		popScore := reviewScore / distancePenalty
+		// Calculate popularity score, balancing rating, number of reviews, and distance
+		popScore := scoreLandmark(float64(place.Rating), float64(place.UserRatingsTotal), distance, s.config.Score) *
+			req.ScoreWeights.maturityFactor(place.UserRatingsTotal)
+		if unrated {
+			popScore = req.UnratedScore
+		}
+
+		// Apply the caller's editorial preference for this kind of place
+		if multiplier, ok := req.CategoryPriority[primaryCategory(place.Types)]; ok {
+			popScore *= multiplier
+		}
+
+		// Enforce the caller's quality floor before ranking
+		if req.MinScore > 0 && popScore < req.MinScore {
+			belowMinScore++
+			continue
+		}
 
 		landmark := Landmark{
 			Name:        place.Name,
@@ -299,6 +1190,15 @@ func (s *LocationService) GetNearbyLandmarks(ctx context.Context, pinCode, city,
 				Lng: place.Geometry.Location.Lng,
 			},
 		}
+		landmark.UID = landmarkUID(landmark.Name, landmark.Location)
+		landmark.Bearing = roundTo(calculateBearing(
+			location.Lat, location.Lng,
+			place.Geometry.Location.Lat, place.Geometry.Location.Lng,
+		), 1)
+		landmark.CompassDirection = compassDirection(landmark.Bearing)
+		if place.OpeningHours != nil {
+			landmark.OpenNow = place.OpeningHours.OpenNow
+		}
 
 		scoredLandmarks = append(scoredLandmarks, scoredLandmark{
 			landmark: landmark,
@@ -306,36 +1206,240 @@ func (s *LocationService) GetNearbyLandmarks(ctx context.Context, pinCode, city,
 		})
 	}
 
-	// Sort by popularity score (highest first)
+	// Sort by the requested order, popularity score (highest first) by default, breaking
+	// ties by rating then name so the order is deterministic across requests
+	less := landmarkOrder(req.SortBy)
 	sort.Slice(scoredLandmarks, func(i, j int) bool {
-		return scoredLandmarks[i].score > scoredLandmarks[j].score
+		return less(scoredLandmarks[i].landmark, scoredLandmarks[j].landmark)
 	})
 
-	// Select top landmarks (up to 5)
-	landmarks := []Landmark{}
-	maxLandmarks := 5
-	if len(scoredLandmarks) < maxLandmarks {
-		maxLandmarks = len(scoredLandmarks)
+	// Rank across the whole set, so it stays the same on every page
+	landmarks := make([]Landmark, len(scoredLandmarks))
+	for i, scored := range scoredLandmarks {
+		landmarks[i] = scored.landmark
+		landmarks[i].Rank = i + 1
 	}
 
-	for i := 0; i < maxLandmarks; i++ {
-		landmarks = append(landmarks, scoredLandmarks[i].landmark)
+	return &scoredSet{
+		Landmarks:      landmarks,
+		RawResultCount: len(places),
+		BelowMinScore:  belowMinScore,
+		BelowMinRating: belowMinRating,
+		FewReviews:     fewReviews,
+		OutsideRadius:  outsideRadius,
+		NameFiltered:   nameFiltered,
+		OriginExcluded: originExcluded,
+		UnratedSkipped: unratedSkipped,
+		WithoutPhotos:  withoutPhotos,
+		TypeFiltered:   typeFiltered,
 	}
+}
 
-	message := fmt.Sprintf("Found %d landmarks near %s", len(landmarks), locationAddress)
-	if len(landmarks) == 0 {
-		message = "No landmarks found in the specified area. Try increasing the search radius."
+// resolveSearchCenter geocodes the request's address or PIN code + city into the
+// point to search around. When both are given, Config.AddressConflictPolicy decides
+// which is used. A non-nil LandmarksResponse reports a user-facing failure.
+func (s *LocationService) resolveSearchCenter(ctx context.Context, req GetLandmarksRequest) (*searchCenter, *LandmarksResponse, error) {
+	pinCode, city, address := req.PinCode, req.City, req.Address
+	useAddress := address != ""
+	usePinCode := pinCode != "" && city != ""
+
+	switch {
+	case useAddress && usePinCode:
+		return s.resolveConflictingCenter(ctx, address, pinCode, city, req.Country)
+	case useAddress:
+		return s.addressCenter(ctx, address)
+	case usePinCode:
+		return s.pinCodeCenter(ctx, pinCode, city, req.Country)
+	default:
+		return nil, &LandmarksResponse{
+			Success: false,
+			Message: "Please provide either an address OR both pin code and city",
+		}, nil
 	}
+}
 
-	return &LandmarksResponse{
-		Success:   true,
-		Message:   message,
-		Landmarks: landmarks,
-		Location: Location{
-			Lat: location.Lat,
-			Lng: location.Lng,
-		},
-	}, nil
+// addressCenter geocodes a street address into a search center
+func (s *LocationService) addressCenter(ctx context.Context, address string) (*searchCenter, *LandmarksResponse, error) {
+	geocodeReq := &maps.GeocodingRequest{
+		Address: strings.TrimSpace(address),
+	}
+
+	geocodeResults, err := s.geocode(ctx, geocodeReq)
+	if err != nil {
+		return nil, nil, fmt.Errorf("geocoding address failed: %w", checkAPIEnabled(geocodingAPI, err))
+	}
+
+	if len(geocodeResults) == 0 {
+		return nil, &LandmarksResponse{
+			Success: false,
+			Message: "Could not find the specified address",
+		}, nil
+	}
+
+	if err := s.checkServiceCountry(geocodeResults[0]); err != nil {
+		return nil, nil, err
+	}
+
+	center := newSearchCenter(geocodeResults[0])
+	center.Name = establishmentName(geocodeResults[0])
+	return center, nil, nil
+}
+
+// pinCodeCenter validates a PIN code against its city and geocodes the pair into a search
+// center. A non-empty country restricts both lookups to that country.
+func (s *LocationService) pinCodeCenter(ctx context.Context, pinCode, city, country string) (*searchCenter, *LandmarksResponse, error) {
+	validation, err := s.ValidatePinCodeWithCity(ctx, pinCode, city, country)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !validation.Valid {
+		return nil, &LandmarksResponse{
+			Success: false,
+			Message: validation.Message,
+		}, nil
+	}
+
+	// Geocode to get exact coordinates
+	geocodeReq := &maps.GeocodingRequest{
+		Address: fmt.Sprintf("%s, %s", pinCode, city),
+	}
+	if country = normalizeCountry(country); country != "" {
+		geocodeReq.Components = map[maps.Component]string{maps.ComponentCountry: country}
+	}
+
+	geocodeResults, err := s.geocode(ctx, geocodeReq)
+	if err != nil {
+		return nil, nil, fmt.Errorf("geocoding failed: %w", checkAPIEnabled(geocodingAPI, err))
+	}
+
+	if len(geocodeResults) == 0 {
+		return nil, &LandmarksResponse{
+			Success: false,
+			Message: "Could not find location coordinates",
+		}, nil
+	}
+
+	return newSearchCenter(geocodeResults[0]), nil, nil
+}
+
+// newSearchCenter builds a search center from a geocoding result
+func newSearchCenter(result maps.GeocodingResult) *searchCenter {
+	return &searchCenter{
+		Location:   result.Geometry.Location,
+		Address:    result.FormattedAddress,
+		Confidence: geocodeConfidence(result),
+		Details:    detailsFromResult(result),
+	}
+}
+
+// geocodeConfidence rates how precisely a geocode result pins down the search center.
+// ROOFTOP is high, RANGE_INTERPOLATED and GEOMETRIC_CENTER are medium, APPROXIMATE is low.
+// A partial match lowers the rating by one level.
+func geocodeConfidence(result maps.GeocodingResult) string {
+	levels := []string{ConfidenceLow, ConfidenceMedium, ConfidenceHigh}
+
+	level := 0
+	switch maps.GeocodeAccuracy(result.Geometry.LocationType) {
+	case maps.GeocodeAccuracyRooftop:
+		level = 2
+	case maps.GeocodeAccuracyRangeInterpolated, maps.GeocodeAccuracyGeometricCenter:
+		level = 1
+	}
+
+	if result.PartialMatch && level > 0 {
+		level--
+	}
+	return levels[level]
+}
+
+// lessLandmark orders landmarks by score descending, then rating descending, then name ascending
+func lessLandmark(a, b Landmark) bool {
+	if a.PopScore != b.PopScore {
+		return a.PopScore > b.PopScore
+	}
+	if a.Rating != b.Rating {
+		return a.Rating > b.Rating
+	}
+	return a.Name < b.Name
+}
+
+// genericPlaceTypes are Google types that describe almost every place and say nothing about its category
+var genericPlaceTypes = map[string]bool{
+	"point_of_interest": true,
+	"establishment":     true,
+}
+
+// primaryCategory returns the first specific type of a place, e.g. "restaurant" for
+// ["restaurant", "food", "point_of_interest", "establishment"]
+func primaryCategory(types []string) string {
+	for _, t := range types {
+		if !genericPlaceTypes[t] {
+			return t
+		}
+	}
+	return ""
+}
+
+// emptyResultMessage explains why a search produced no landmarks, using the pipeline's
+// intermediate counts to say whether Google returned nothing or our filters removed everything
+func emptyResultMessage(set *scoredSet, req GetLandmarksRequest) string {
+	if set.RawResultCount == 0 {
+		return fmt.Sprintf("No landmarks found: Google returned no places within %.0fm. Try increasing the search radius.", set.RadiusUsed)
+	}
+
+	var reasons, hints []string
+	if set.OriginExcluded > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d matched the search location itself", set.OriginExcluded))
+	}
+	if set.OutsideRadius > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d were beyond the exact radius", set.OutsideRadius))
+		hints = append(hints, "turn off strict_radius")
+	}
+	if set.UnratedSkipped > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d had no reviews", set.UnratedSkipped))
+		hints = append(hints, "set include_unrated")
+	}
+	if set.FewReviews > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d had fewer than %d reviews", set.FewReviews, req.MinReviews))
+		hints = append(hints, "lower min_reviews")
+	}
+	if set.BelowMinRating > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d were rated below %.1f", set.BelowMinRating, req.MinRating))
+		hints = append(hints, "lower min_rating")
+	}
+	if set.WithoutPhotos > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d had no photos", set.WithoutPhotos))
+		hints = append(hints, "turn off require_photos")
+	}
+	if set.Not24Hours > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d were not open 24 hours or had no opening hours", set.Not24Hours))
+		hints = append(hints, "turn off open_24_hours")
+	}
+	if set.HoursUnknown > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d couldn't be checked for opening hours", set.HoursUnknown))
+		hints = append(hints, "try again")
+	}
+	if set.HoursUnchecked > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d were beyond the %d places checked for opening hours", set.HoursUnchecked, maxOpen24HoursLookups))
+		hints = append(hints, "narrow the search with types")
+	}
+	if set.TypeFiltered > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d were not of the requested types", set.TypeFiltered))
+		hints = append(hints, "add more types")
+	}
+	if set.NameFiltered > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d did not have a name containing %q", set.NameFiltered, req.NameFilter))
+		hints = append(hints, "shorten or remove name_filter")
+	}
+	if set.BelowMinScore > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d scored below the minimum popularity score of %.2f", set.BelowMinScore, req.MinScore))
+		hints = append(hints, "lower min_score")
+	}
+	hints = append(hints, "increase the search radius")
+
+	return fmt.Sprintf("No landmarks found: Google returned %d places within %.0fm but all were filtered out (%s). Try to %s.",
+		set.RawResultCount, set.RadiusUsed, strings.Join(reasons, ", "), strings.Join(hints, ", or "))
 }
 
 // calculateDistance calculates distance between two coordinates in meters using Haversine formula
@@ -358,67 +1462,162 @@ func calculateDistance(lat1, lon1, lat2, lon2 float64) float64 {
 	return earthRadius * c
 }
 
+// calculateBearing returns the initial great-circle bearing from the first coordinate to
+// the second, in degrees clockwise from north within [0, 360)
+func calculateBearing(lat1, lon1, lat2, lon2 float64) float64 {
+	lat1Rad := lat1 * math.Pi / 180
+	lat2Rad := lat2 * math.Pi / 180
+	deltaLon := (lon2 - lon1) * math.Pi / 180
+
+	y := math.Sin(deltaLon) * math.Cos(lat2Rad)
+	x := math.Cos(lat1Rad)*math.Sin(lat2Rad) - math.Sin(lat1Rad)*math.Cos(lat2Rad)*math.Cos(deltaLon)
+	bearing := math.Atan2(y, x) * 180 / math.Pi
+	return math.Mod(bearing+360, 360)
+}
+
+// compassPoints are the 8 compass directions, clockwise from north
+var compassPoints = []string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"}
+
+// compassDirection maps a bearing in degrees to the nearest of the 8 compass points. Each
+// point covers 45 degrees centered on it, so N is [337.5, 22.5) and NE is [22.5, 67.5).
+func compassDirection(bearing float64) string {
+	index := int(math.Floor(math.Mod(bearing+22.5, 360)/45)) % len(compassPoints)
+	if index < 0 {
+		index += len(compassPoints)
+	}
+	return compassPoints[index]
+}
+
 // HTTP Handlers
 func (s *LocationService) handleValidatePinCode(w http.ResponseWriter, r *http.Request) {
 	var req ValidatePinCodeRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if err := s.decodeJSON(r.Body, &req); err != nil {
+		logRequest(r.Context(), "Invalid validation request body: %v", err)
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid request body")
 		return
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
+	ctx = withRequestID(ctx, requestIDFrom(r.Context()))
+	ctx, trace := s.debugContext(ctx)
+	ctx, calls := s.apiCallContext(ctx, r.Header.Get("X-Include-API-Calls"))
 
-	response, err := s.ValidatePinCodeWithCity(ctx, req.PinCode, req.City)
+	response, err := s.validatePinCode(ctx, req)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Validation failed: %v", err), http.StatusInternalServerError)
+		logRequest(ctx, "Validation of PIN %q failed: %v", req.PinCode, err)
+		writeServiceError(w, err, "Validation failed")
 		return
 	}
+	if trace != nil {
+		response.GeocodeQueries = trace.Queries()
+	}
+	if calls != nil {
+		response.APICalls = calls.Snapshot()
+	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	writeResponse(w, r, response)
+}
+
+// validatePinCode runs one validation request in its resolved language: ResolvePinCode
+// when an empty city should be resolved, ValidatePinCodeWithCity otherwise
+func (s *LocationService) validatePinCode(ctx context.Context, req ValidatePinCodeRequest) (*ValidationResponse, error) {
+	language := s.resolveLanguage(req.Language, mapsLanguages)
+	ctx = withLanguage(ctx, language)
+
+	var response *ValidationResponse
+	var err error
+	if req.ResolveCity && strings.TrimSpace(req.City) == "" {
+		response, err = s.ResolvePinCode(ctx, req.PinCode, req.Country)
+	} else {
+		response, err = s.ValidatePinCodeWithCity(ctx, req.PinCode, req.City, req.Country)
+	}
+	if err != nil {
+		return nil, err
+	}
+	response.Language = language
+	response.MessageLanguage = s.resolveLanguage(req.Language, messageLanguages)
+	return response, nil
 }
 
+// handleGetLandmarks serves landmark searches from a JSON body (POST) or, with the same
+// parameter names, from the query string (GET)
 func (s *LocationService) handleGetLandmarks(w http.ResponseWriter, r *http.Request) {
 	var req GetLandmarksRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if r.Method == http.MethodGet {
+		query := r.URL.Query()
+		applyParamAliases(query, s.config.ParamAliases)
+		var err error
+		if req, err = landmarksRequestFromQuery(query); err != nil {
+			writeError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid query: "+err.Error())
+			return
+		}
+	} else if err := s.decodeJSON(r.Body, &req); err != nil {
+		logRequest(r.Context(), "Invalid landmarks request body: %v", err)
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid request body")
+		return
+	}
+
+	if req.Limit < 0 {
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid limit: must not be negative")
+		return
+	}
+	if req.Radius < 0 {
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid radius: must not be negative")
+		return
+	}
+	if _, ok := parseSortBy(req.SortBy); !ok {
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidRequest,
+			fmt.Sprintf("Invalid sort_by %q: use popularity, distance, rating, reviews or travel_duration", req.SortBy))
 		return
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
+	ctx = withRequestID(ctx, requestIDFrom(r.Context()))
+	ctx, trace := s.debugContext(ctx)
+	ctx, calls := s.apiCallContext(ctx, r.Header.Get("X-Include-API-Calls"))
 
-	response, err := s.GetNearbyLandmarks(ctx, req.PinCode, req.City, req.Address, req.Radius)
+	response, err := s.GetNearbyLandmarks(ctx, req)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to get landmarks: %v", err), http.StatusInternalServerError)
+		logRequest(ctx, "Landmark search failed: %v", err)
+		writeServiceError(w, err, "Failed to get landmarks")
+		return
+	}
+	if trace != nil {
+		response.GeocodeQueries = trace.Queries()
+	}
+	if calls != nil {
+		response.APICalls = calls.Snapshot()
+	}
+	// A GET search may be reused by the browser for as long as the service would reuse it
+	if r.Method == http.MethodGet && response.Success && s.config.ResultCacheTTL > 0 {
+		w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d", int(s.config.ResultCacheTTL.Seconds())))
+	}
+
+	// Serve schema.org markup for server-rendered pages
+	if r.URL.Query().Get("format") == "jsonld" && response.Success {
+		w.Header().Set("Content-Type", "application/ld+json")
+		json.NewEncoder(w).Encode(toJSONLD(response.Landmarks))
+		return
+	}
+	// Printable sheet for field teams
+	if r.URL.Query().Get("format") == "pdf" && response.Success {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Disposition", `attachment; filename="landmarks.pdf"`)
+		if _, err := w.Write(toPDF(response)); err != nil {
+			logRequest(ctx, "Writing PDF failed: %v", err)
+		}
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	writeResponse(w, r, response)
 }
 
-// Middleware for logging
+// Middleware for logging; runs inside requestIDMiddleware so each line carries the request ID
 func loggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		log.Printf("[%s] %s %s", r.Method, r.RequestURI, r.RemoteAddr)
-		next.ServeHTTP(w, r)
-	})
-}
-
-// CORS middleware
-func corsMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
-			return
-		}
-
+		logRequest(r.Context(), "%s %s %s", r.Method, r.RequestURI, r.RemoteAddr)
 		next.ServeHTTP(w, r)
 	})
 }
@@ -436,31 +1635,58 @@ func main() {
 	}
 
 	// Initialize service
-	service, err := NewLocationService(apiKey)
+	config := loadConfig()
+
+	service, err := NewLocationService(apiKey, config)
 	if err != nil {
 		log.Fatalf("Failed to initialize location service: %v", err)
 	}
 
+	// Catch keys that are missing a required Maps API before the first request does
+	service.warnOnMissingAPIs()
+
 	// Setup routes
 	router := mux.NewRouter()
 
 	// === API endpoints ===
 	router.HandleFunc("/api/validate-pincode", service.handleValidatePinCode).Methods("POST", "OPTIONS")
-	router.HandleFunc("/api/get-landmarks", service.handleGetLandmarks).Methods("POST", "OPTIONS")
+	router.HandleFunc("/api/validate-pincode/batch", service.handleValidatePinCodeBatch).Methods("POST", "OPTIONS")
+	router.HandleFunc("/api/reverse-geocode", service.handleReverseGeocode).Methods("POST", "OPTIONS")
+	router.HandleFunc("/api/get-landmarks", service.handleGetLandmarks).Methods("GET", "POST", "OPTIONS")
+	router.HandleFunc("/api/ring-counts", service.handleRingCounts).Methods("POST", "OPTIONS")
+	router.HandleFunc("/api/landmarks/stream", service.handleStreamLandmarks).Methods("GET")
+	router.HandleFunc("/api/landmarks/batch/stream", service.handleStreamBatchLandmarks).Methods("POST", "OPTIONS")
+	router.HandleFunc("/api/neighborhoods", service.handleNeighborhoods).Methods("POST", "OPTIONS")
+	router.HandleFunc("/api/drivetime-grid", service.handleDriveTimeGrid).Methods("POST", "OPTIONS")
+	router.HandleFunc("/api/normalize-address", service.handleNormalizeAddress).Methods("POST", "OPTIONS")
+	router.HandleFunc("/api/distance", service.handleDistance).Methods("POST", "OPTIONS")
+	router.HandleFunc("/api/place/{place_id}", service.handleGetPlace).Methods("GET")
+	router.HandleFunc("/api/photo", service.handlePhoto).Methods("GET")
+
+	// Admin endpoints (require ADMIN_API_KEY)
+	router.HandleFunc("/admin/cache/stats", service.requireAdminKey(service.handleCacheStats)).Methods("GET")
 
 	// Health check
 	router.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(map[string]string{"status": "healthy"})
 	}).Methods("GET")
+	router.HandleFunc("/readyz", service.handleReadyz).Methods("GET")
+	if service.metrics != nil {
+		router.HandleFunc("/metrics", service.metrics.handleMetrics).Methods("GET")
+	}
 
 	// === Serve static frontend ===
 	// Put index.html and assets inside ./static/
 	fs := http.FileServer(http.Dir("./static"))
 	router.PathPrefix("/").Handler(fs)
 
-	// Apply middleware
-	handler := loggingMiddleware(corsMiddleware(router))
+	// Apply middleware. The rate limiter runs inside CORS so 429s still carry CORS headers.
+	var limiter *rateLimiter
+	if config.RateLimitPerMinute > 0 {
+		limiter = newRateLimiter(config.RateLimitPerMinute)
+	}
+	handler := recoverMiddleware(requestIDMiddleware(service.metrics.middleware(router, gzipMiddleware(loggingMiddleware(corsMiddleware(config, limiter.middleware(config.TrustForwardedFor, router)))))))
 
 	// Start server
 	port := os.Getenv("PORT")
@@ -471,11 +1697,29 @@ func main() {
 	log.Printf("Starting server on port %s", port)
 	log.Printf("Endpoints:")
 	log.Printf("  POST /api/validate-pincode - Validate PIN code with city")
+	log.Printf("  POST /api/validate-pincode/batch - Validate up to %d PIN codes at once", maxPinBatchSize)
+	log.Printf("  POST /api/reverse-geocode - PIN code and city for coordinates")
 	log.Printf("  POST /api/get-landmarks - Get nearby landmarks (supports address or pin+city)")
+	log.Printf("  POST /api/ring-counts - Count places per distance ring")
+	log.Printf("  GET  /api/landmarks/stream - Stream all scored landmarks as server-sent events")
+	log.Printf("  POST /api/landmarks/batch/stream - Stream landmarks for several locations as NDJSON")
+	log.Printf("  POST /api/neighborhoods - Named neighborhoods around a location")
+	log.Printf("  POST /api/drivetime-grid - Drive times from a center to a grid of points")
+	log.Printf("  POST /api/normalize-address - Structured, label-ready address")
+	log.Printf("  POST /api/distance - Straight-line distance between two points")
+	log.Printf("  GET  /api/place/{place_id} - Full details for a landmark's place ID")
+	log.Printf("  GET  /api/photo - Place photo proxied through the server")
+	log.Printf("  GET  /admin/cache/stats - Cache statistics (admin)")
 	log.Printf("  GET  /health - Health check")
+	log.Printf("  GET  /readyz - Readiness check (Google Maps reachable)")
+	if service.metrics != nil {
+		log.Printf("  GET  /metrics - Prometheus metrics")
+	}
 	log.Printf("  GET  /        - Frontend UI")
 
-	if err := http.ListenAndServe(":"+port, handler); err != nil {
-		log.Fatalf("Server failed to start: %v", err)
+	server := &http.Server{Addr: ":" + port, Handler: handler}
+	if err := serve(server, config.ShutdownGracePeriod); err != nil {
+		log.Fatalf("Server failed: %v", err)
 	}
+	log.Printf("Server stopped")
 }
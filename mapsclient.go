@@ -0,0 +1,23 @@
+package main
+
+import (
+	"context"
+
+	"googlemaps.github.io/maps"
+)
+
+// MapsClient is the subset of *maps.Client the service uses. Depending on it rather than
+// the concrete client lets a fake stand in for Google, e.g. when exercising scoring and
+// sorting without real API calls.
+type MapsClient interface {
+	Geocode(ctx context.Context, r *maps.GeocodingRequest) ([]maps.GeocodingResult, error)
+	ReverseGeocode(ctx context.Context, r *maps.GeocodingRequest) ([]maps.GeocodingResult, error)
+	NearbySearch(ctx context.Context, r *maps.NearbySearchRequest) (maps.PlacesSearchResponse, error)
+	PlaceDetails(ctx context.Context, r *maps.PlaceDetailsRequest) (maps.PlaceDetailsResult, error)
+	DistanceMatrix(ctx context.Context, r *maps.DistanceMatrixRequest) (*maps.DistanceMatrixResponse, error)
+	NearestRoads(ctx context.Context, r *maps.NearestRoadsRequest) (*maps.NearestRoadsResponse, error)
+	PlacePhoto(ctx context.Context, r *maps.PlacePhotoRequest) (maps.PlacePhotoResponse, error)
+}
+
+// Compile-time check that the real client satisfies MapsClient
+var _ MapsClient = (*maps.Client)(nil)
@@ -0,0 +1,339 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"googlemaps.github.io/maps"
+)
+
+// testCenter is where fake geocodes put every search center (Kanpur)
+var testCenter = maps.LatLng{Lat: 26.4499, Lng: 80.3319}
+
+// errNotFaked is returned by fake calls a test didn't set up
+var errNotFaked = errors.New("not faked")
+
+// fakeMapsClient is a MapsClient serving canned results, so the service can be tested
+// without calling Google. Unset results make the call fail with errNotFaked.
+type fakeMapsClient struct {
+	// geocodes maps a geocode request's address to its results; unknown addresses get none
+	geocodes map[string][]maps.GeocodingResult
+	// reverseGeocodes is returned for every reverse geocode
+	reverseGeocodes []maps.GeocodingResult
+	// places is returned as the single page of every nearby search
+	places []maps.PlacesSearchResult
+	// details maps place IDs to Place Details; unknown IDs fail with NOT_FOUND
+	details map[string]maps.PlaceDetailsResult
+	// failures maps a call name to errors its next calls return, one per call, before it
+	// serves results again
+	failures map[string][]error
+
+	mu    sync.Mutex
+	calls map[string]int
+	// geocodeRequests are the forward geocodes sent, in order
+	geocodeRequests []maps.GeocodingRequest
+	// nearbyRequests are the nearby searches sent, in order
+	nearbyRequests []maps.NearbySearchRequest
+}
+
+// record counts a call and returns the next scripted failure for it, if any
+func (f *fakeMapsClient) record(call string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.calls == nil {
+		f.calls = make(map[string]int)
+	}
+	f.calls[call]++
+	if queue := f.failures[call]; len(queue) > 0 {
+		f.failures[call] = queue[1:]
+		return queue[0]
+	}
+	return nil
+}
+
+// callCount returns how many times the named call was made
+func (f *fakeMapsClient) callCount(call string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[call]
+}
+
+func (f *fakeMapsClient) Geocode(ctx context.Context, r *maps.GeocodingRequest) ([]maps.GeocodingResult, error) {
+	if err := f.record("geocode"); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	f.geocodeRequests = append(f.geocodeRequests, *r)
+	f.mu.Unlock()
+	return f.geocodes[r.Address], nil
+}
+
+func (f *fakeMapsClient) ReverseGeocode(ctx context.Context, r *maps.GeocodingRequest) ([]maps.GeocodingResult, error) {
+	if err := f.record("reverse_geocode"); err != nil {
+		return nil, err
+	}
+	if f.reverseGeocodes == nil {
+		return nil, errNotFaked
+	}
+	return f.reverseGeocodes, nil
+}
+
+func (f *fakeMapsClient) NearbySearch(ctx context.Context, r *maps.NearbySearchRequest) (maps.PlacesSearchResponse, error) {
+	if err := f.record("nearby"); err != nil {
+		return maps.PlacesSearchResponse{}, err
+	}
+	f.mu.Lock()
+	f.nearbyRequests = append(f.nearbyRequests, *r)
+	f.mu.Unlock()
+	return maps.PlacesSearchResponse{Results: f.places}, nil
+}
+
+func (f *fakeMapsClient) PlaceDetails(ctx context.Context, r *maps.PlaceDetailsRequest) (maps.PlaceDetailsResult, error) {
+	if err := f.record("details"); err != nil {
+		return maps.PlaceDetailsResult{}, err
+	}
+	details, ok := f.details[r.PlaceID]
+	if !ok {
+		return maps.PlaceDetailsResult{}, errors.New("maps: NOT_FOUND - ")
+	}
+	return details, nil
+}
+
+func (f *fakeMapsClient) DistanceMatrix(ctx context.Context, r *maps.DistanceMatrixRequest) (*maps.DistanceMatrixResponse, error) {
+	if err := f.record("distance_matrix"); err != nil {
+		return nil, err
+	}
+	return nil, errNotFaked
+}
+
+func (f *fakeMapsClient) NearestRoads(ctx context.Context, r *maps.NearestRoadsRequest) (*maps.NearestRoadsResponse, error) {
+	if err := f.record("nearest_roads"); err != nil {
+		return nil, err
+	}
+	return nil, errNotFaked
+}
+
+func (f *fakeMapsClient) PlacePhoto(ctx context.Context, r *maps.PlacePhotoRequest) (maps.PlacePhotoResponse, error) {
+	if err := f.record("photo"); err != nil {
+		return maps.PlacePhotoResponse{}, err
+	}
+	return maps.PlacePhotoResponse{}, errNotFaked
+}
+
+// newTestService returns a service with default settings around client
+func newTestService(t *testing.T, client MapsClient) *LocationService {
+	t.Helper()
+	return newLocationService(client, loadConfig())
+}
+
+// fakeAddressGeocode is a geocode result for address at testCenter
+func fakeAddressGeocode(address string) []maps.GeocodingResult {
+	result := maps.GeocodingResult{FormattedAddress: address}
+	result.Geometry.Location = testCenter
+	result.Geometry.LocationType = string(maps.GeocodeAccuracyRooftop)
+	return []maps.GeocodingResult{result}
+}
+
+// fakePlace is a nearby search result metersNorth of testCenter
+func fakePlace(name string, rating float32, reviews int, metersNorth float64) maps.PlacesSearchResult {
+	place := maps.PlacesSearchResult{
+		Name:             name,
+		PlaceID:          "id-" + name,
+		Rating:           rating,
+		UserRatingsTotal: reviews,
+		Types:            []string{"tourist_attraction", "point_of_interest", "establishment"},
+		Vicinity:         name + " Road",
+	}
+	place.Geometry.Location = maps.LatLng{Lat: testCenter.Lat + metersNorth/111195, Lng: testCenter.Lng}
+	return place
+}
+
+// newAddressClient is a fake that geocodes "1 Mall Road" to testCenter and finds places
+// around it
+func newAddressClient(places []maps.PlacesSearchResult) *fakeMapsClient {
+	return &fakeMapsClient{
+		geocodes: map[string][]maps.GeocodingResult{"1 Mall Road": fakeAddressGeocode("1 Mall Road")},
+		places:   places,
+	}
+}
+
+// searchNames runs a landmark search around the fake address and returns the landmark
+// names in response order
+func searchNames(t *testing.T, places []maps.PlacesSearchResult, req GetLandmarksRequest) []string {
+	t.Helper()
+	client := newAddressClient(places)
+	req.Address = "1 Mall Road"
+	response, err := newTestService(t, client).GetNearbyLandmarks(context.Background(), req)
+	if err != nil {
+		t.Fatalf("GetNearbyLandmarks: %v", err)
+	}
+	if !response.Success {
+		t.Fatalf("GetNearbyLandmarks failed: %s", response.Message)
+	}
+	return landmarkNames(response.Landmarks)
+}
+
+func landmarkNames(landmarks []Landmark) []string {
+	names := make([]string, len(landmarks))
+	for i, landmark := range landmarks {
+		names[i] = landmark.Name
+	}
+	return names
+}
+
+func TestGetNearbyLandmarksTopFive(t *testing.T) {
+	tests := []struct {
+		name   string
+		places []maps.PlacesSearchResult
+		want   []string
+	}{
+		{
+			name: "keeps the five most popular in score order",
+			places: []maps.PlacesSearchResult{
+				fakePlace("Fort", 4.0, 100, 500),    // 4.0*2.004/1.5 = 5.35
+				fakePlace("Temple", 4.8, 5000, 500), // 4.8*3.699/1.5 = 11.84
+				fakePlace("Museum", 4.5, 1000, 200), // 4.5*3.000/1.2 = 11.25
+				fakePlace("Garden", 4.2, 50, 100),   // 4.2*1.708/1.1 = 6.52
+				fakePlace("Lake", 3.9, 300, 1000),   // 3.9*2.479/2.0 = 4.83
+				fakePlace("Zoo", 4.4, 2000, 3000),   // 4.4*3.301/4.0 = 3.63
+				fakePlace("Ghat", 4.1, 800, 300),    // 4.1*2.904/1.3 = 9.16
+			},
+			want: []string{"Temple", "Museum", "Ghat", "Garden", "Fort"},
+		},
+		{
+			name: "nearer wins at equal rating and reviews",
+			places: []maps.PlacesSearchResult{
+				fakePlace("Far", 4.5, 100, 2000),
+				fakePlace("Near", 4.5, 100, 100),
+				fakePlace("Middle", 4.5, 100, 800),
+			},
+			want: []string{"Near", "Middle", "Far"},
+		},
+		{
+			name: "skips unrated places and the search location itself",
+			places: []maps.PlacesSearchResult{
+				fakePlace("Unrated", 0, 0, 100),
+				fakePlace("Here", 5.0, 9000, 0),
+				fakePlace("Market", 4.0, 10, 400),
+			},
+			want: []string{"Market"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := searchNames(t, tt.places, GetLandmarksRequest{})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("landmarks = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetNearbyLandmarksCachesResults(t *testing.T) {
+	client := newAddressClient([]maps.PlacesSearchResult{fakePlace("Fort", 4.0, 100, 500)})
+	service := newTestService(t, client)
+	req := GetLandmarksRequest{Address: "1 Mall Road"}
+	for i := 0; i < 2; i++ {
+		if _, err := service.GetNearbyLandmarks(context.Background(), req); err != nil {
+			t.Fatalf("search %d: %v", i+1, err)
+		}
+	}
+	// The second search is answered by the result cache
+	if got := client.callCount("geocode"); got != 1 {
+		t.Errorf("geocode calls = %d, want 1", got)
+	}
+	if got := client.callCount("nearby"); got != 1 {
+		t.Errorf("nearby calls = %d, want 1", got)
+	}
+}
+
+func TestGetNearbyLandmarksMinReviews(t *testing.T) {
+	places := []maps.PlacesSearchResult{
+		fakePlace("None", 0, 0, 100),
+		fakePlace("One", 4.0, 1, 200),
+		fakePlace("Five", 4.0, 5, 300),
+	}
+	tests := []struct {
+		name string
+		req  GetLandmarksRequest
+		want []string
+	}{
+		{"unset keeps every rated place", GetLandmarksRequest{}, []string{"Five", "One"}},
+		{"one keeps every rated place", GetLandmarksRequest{MinReviews: 1}, []string{"Five", "One"}},
+		{"five drops the place with one review", GetLandmarksRequest{MinReviews: 5}, []string{"Five"}},
+		{"six drops both", GetLandmarksRequest{MinReviews: 6}, []string{}},
+		{
+			"unrated places are governed by include_unrated",
+			GetLandmarksRequest{MinReviews: 5, IncludeUnrated: true, UnratedScore: 0.5},
+			[]string{"Five", "None"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := searchNames(t, places, tt.req)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("landmarks = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetNearbyLandmarksMinReviewsMessage(t *testing.T) {
+	client := newAddressClient([]maps.PlacesSearchResult{fakePlace("One", 4.0, 1, 200)})
+	response, err := newTestService(t, client).GetNearbyLandmarks(context.Background(),
+		GetLandmarksRequest{Address: "1 Mall Road", MinReviews: 5})
+	if err != nil {
+		t.Fatalf("GetNearbyLandmarks: %v", err)
+	}
+	if !strings.Contains(response.Message, "1 had fewer than 5 reviews") {
+		t.Errorf("message = %q, want it to count the place with too few reviews", response.Message)
+	}
+}
+
+// Compile-time check that the fake satisfies MapsClient
+var _ MapsClient = (*fakeMapsClient)(nil)
+
+func TestGetNearbyLandmarksRadius(t *testing.T) {
+	tests := []struct {
+		radius     float64
+		wantOK     bool
+		wantRadius uint
+		wantNote   bool
+	}{
+		{radius: 0, wantOK: true, wantRadius: 1000},
+		{radius: -5, wantOK: false},
+		{radius: 2000, wantOK: true, wantRadius: 2000},
+		{radius: 60000, wantOK: true, wantRadius: 50000, wantNote: true},
+	}
+
+	for _, tt := range tests {
+		client := newAddressClient([]maps.PlacesSearchResult{fakePlace("Fort", 4.0, 100, 500)})
+		response, err := newTestService(t, client).GetNearbyLandmarks(context.Background(),
+			GetLandmarksRequest{Address: "1 Mall Road", Radius: tt.radius})
+		if err != nil {
+			t.Fatalf("radius %v: %v", tt.radius, err)
+		}
+		if response.Success != tt.wantOK {
+			t.Errorf("radius %v: success = %v, want %v (%s)", tt.radius, response.Success, tt.wantOK, response.Message)
+			continue
+		}
+		if !tt.wantOK {
+			if client.callCount("nearby") != 0 {
+				t.Errorf("radius %v: searched despite the invalid radius", tt.radius)
+			}
+			continue
+		}
+		if got := client.nearbyRequests[0].Radius; got != tt.wantRadius {
+			t.Errorf("radius %v: searched %dm, want %dm", tt.radius, got, tt.wantRadius)
+		}
+		if got := strings.Contains(response.Message, "radius clamped"); got != tt.wantNote {
+			t.Errorf("radius %v: message %q, clamp noted = %v, want %v", tt.radius, response.Message, got, tt.wantNote)
+		}
+	}
+}
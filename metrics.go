@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"googlemaps.github.io/maps"
+)
+
+// latencyBuckets are the histogram upper bounds in seconds, Prometheus' defaults
+var latencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// histogram counts observations per latency bucket in the Prometheus sense: each bucket
+// counts observations at or below its bound
+type histogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+func (h *histogram) observe(seconds float64) {
+	if h.counts == nil {
+		h.counts = make([]uint64, len(latencyBuckets))
+	}
+	for i, bound := range latencyBuckets {
+		if seconds <= bound {
+			h.counts[i]++
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+// serviceMetrics collects request and Maps API metrics for the /metrics endpoint. Labels
+// are joined with "|" into map keys; route labels are mux path templates, so IDs in paths
+// don't create new series.
+type serviceMetrics struct {
+	mu              sync.Mutex
+	requests        map[string]uint64     // route|method|status
+	requestLatency  map[string]*histogram // route
+	mapsCalls       map[string]uint64     // call|outcome
+	mapsCallLatency map[string]*histogram // call
+}
+
+func newServiceMetrics() *serviceMetrics {
+	return &serviceMetrics{
+		requests:        make(map[string]uint64),
+		requestLatency:  make(map[string]*histogram),
+		mapsCalls:       make(map[string]uint64),
+		mapsCallLatency: make(map[string]*histogram),
+	}
+}
+
+// observeRequest records one served request
+func (m *serviceMetrics) observeRequest(route, method string, status int, elapsed time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[route+"|"+method+"|"+strconv.Itoa(status)]++
+	if m.requestLatency[route] == nil {
+		m.requestLatency[route] = &histogram{}
+	}
+	m.requestLatency[route].observe(elapsed.Seconds())
+}
+
+// observeMapsCall records one Maps API call and whether it failed
+func (m *serviceMetrics) observeMapsCall(call string, err error, elapsed time.Duration) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mapsCalls[call+"|"+outcome]++
+	if m.mapsCallLatency[call] == nil {
+		m.mapsCallLatency[call] = &histogram{}
+	}
+	m.mapsCallLatency[call].observe(elapsed.Seconds())
+}
+
+// statusRecorder captures the status code written by a handler. It passes Flush through
+// so streaming endpoints keep working.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(p []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.ResponseWriter.Write(p)
+}
+
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// middleware counts requests per route, method and status and times them. It wraps the
+// other middleware, so rate-limited and CORS preflight requests are counted too; requests
+// matching no route are labeled "unmatched" and panics count as 500. A nil serviceMetrics
+// lets everything through.
+func (m *serviceMetrics) middleware(router *mux.Router, next http.Handler) http.Handler {
+	if m == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := "unmatched"
+		var match mux.RouteMatch
+		if router.Match(r, &match) && match.Route != nil {
+			if template, err := match.Route.GetPathTemplate(); err == nil {
+				route = template
+			}
+		}
+
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w}
+		completed := false
+		defer func() {
+			// A panicking handler is answered with 500 by recoverMiddleware further out
+			status := recorder.status
+			if !completed {
+				status = http.StatusInternalServerError
+			} else if status == 0 {
+				status = http.StatusOK
+			}
+			m.observeRequest(route, r.Method, status, time.Since(start))
+		}()
+		next.ServeHTTP(recorder, r)
+		completed = true
+	})
+}
+
+// handleMetrics serves the metrics in the Prometheus text exposition format
+func (m *serviceMetrics) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	writeCounter(w, "http_requests_total", "HTTP requests served, by route, method and status.",
+		[]string{"route", "method", "status"}, m.requests)
+	writeHistogram(w, "http_request_duration_seconds", "Time to serve HTTP requests, by route.",
+		"route", m.requestLatency)
+	writeCounter(w, "maps_api_requests_total", "Google Maps API calls, by call and outcome (success or error).",
+		[]string{"call", "outcome"}, m.mapsCalls)
+	writeHistogram(w, "maps_api_request_duration_seconds", "Google Maps API call latency, by call.",
+		"call", m.mapsCallLatency)
+}
+
+// writeCounter writes a counter family; each key holds the label values joined by "|"
+func writeCounter(w io.Writer, name, help string, labels []string, values map[string]uint64) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+	for _, key := range sortedKeys(values) {
+		fmt.Fprintf(w, "%s{%s} %d\n", name, labelPairs(labels, strings.Split(key, "|")), values[key])
+	}
+}
+
+// writeHistogram writes a histogram family with one label
+func writeHistogram(w io.Writer, name, help, label string, values map[string]*histogram) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", name, help, name)
+	for _, key := range sortedKeys(values) {
+		h := values[key]
+		pair := labelPairs([]string{label}, []string{key})
+		for i, bound := range latencyBuckets {
+			fmt.Fprintf(w, "%s_bucket{%s,le=\"%s\"} %d\n", name, pair, strconv.FormatFloat(bound, 'g', -1, 64), h.counts[i])
+		}
+		fmt.Fprintf(w, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, pair, h.count)
+		fmt.Fprintf(w, "%s_sum{%s} %g\n", name, pair, h.sum)
+		fmt.Fprintf(w, "%s_count{%s} %d\n", name, pair, h.count)
+	}
+}
+
+// labelValueEscaper escapes label values as the exposition format requires
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// labelPairs formats label names and values as name="value",... with values escaped
+func labelPairs(names, values []string) string {
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = name + `="` + labelValueEscaper.Replace(values[i]) + `"`
+	}
+	return strings.Join(pairs, ",")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// instrumentedClient wraps a MapsClient to record the latency and outcome of Geocode and
+// NearbySearch calls, the two calls on every landmark search's path. Each attempt is
+// recorded, so retries show up as separate calls.
+type instrumentedClient struct {
+	MapsClient
+	metrics *serviceMetrics
+}
+
+func (c instrumentedClient) Geocode(ctx context.Context, r *maps.GeocodingRequest) ([]maps.GeocodingResult, error) {
+	start := time.Now()
+	results, err := c.MapsClient.Geocode(ctx, r)
+	c.metrics.observeMapsCall("geocode", err, time.Since(start))
+	return results, err
+}
+
+func (c instrumentedClient) NearbySearch(ctx context.Context, r *maps.NearbySearchRequest) (maps.PlacesSearchResponse, error) {
+	start := time.Now()
+	response, err := c.MapsClient.NearbySearch(ctx, r)
+	c.metrics.observeMapsCall("nearby", err, time.Since(start))
+	return response, err
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"googlemaps.github.io/maps"
+)
+
+// neighborhoodResultTypes are the reverse-geocode result types treated as named areas
+var neighborhoodResultTypes = []string{"sublocality", "neighborhood"}
+
+// maxNeighborhoodRadius caps how far from the center neighborhoods are sampled, in meters
+const maxNeighborhoodRadius = 5000
+
+type NeighborhoodsRequest struct {
+	PinCode string `json:"pin_code,omitempty"`
+	City    string `json:"city,omitempty"`
+	Address string `json:"address,omitempty"`
+	// Lat/Lng can be given instead of an address or PIN code
+	Lat *float64 `json:"lat,omitempty"`
+	Lng *float64 `json:"lng,omitempty"`
+	// Radius is how far from the center to look for neighborhoods, in meters (default 1000)
+	Radius float64 `json:"radius,omitempty"`
+}
+
+type NeighborhoodsResponse struct {
+	Success       bool           `json:"success"`
+	Message       string         `json:"message"`
+	Neighborhoods []Neighborhood `json:"neighborhoods"`
+	Location      Location       `json:"location"`
+}
+
+type Neighborhood struct {
+	Name     string   `json:"name"`
+	Location Location `json:"location"` // approximate center of the area
+	Distance float64  `json:"distance"` // meters from the search center
+}
+
+// reverseGeocode looks up the address results at a point, optionally limited to result types
+func (s *LocationService) reverseGeocode(ctx context.Context, point maps.LatLng, resultTypes ...string) ([]maps.GeocodingResult, error) {
+	results, err := s.mapsClient.ReverseGeocode(ctx, &maps.GeocodingRequest{
+		LatLng:     &point,
+		ResultType: resultTypes,
+		Language:   s.languageFrom(ctx),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reverse geocoding failed: %w", checkAPIEnabled(geocodingAPI, err))
+	}
+	return results, nil
+}
+
+// GetNeighborhoods lists the named areas at and around the search center. It reverse-geocodes
+// the center plus four points at the given radius (north, east, south, west), so it costs
+// five Geocoding calls. Neighborhoods are deduplicated by name and sorted by distance.
+func (s *LocationService) GetNeighborhoods(ctx context.Context, req NeighborhoodsRequest) (*NeighborhoodsResponse, error) {
+	radius := req.Radius
+	if radius <= 0 {
+		radius = 1000
+	}
+	if radius > maxNeighborhoodRadius {
+		radius = maxNeighborhoodRadius
+	}
+
+	var center maps.LatLng
+	if req.Lat != nil && req.Lng != nil {
+		center = maps.LatLng{Lat: *req.Lat, Lng: *req.Lng}
+	} else {
+		resolved, failure, err := s.resolveSearchCenter(ctx, GetLandmarksRequest{
+			PinCode: req.PinCode,
+			City:    req.City,
+			Address: req.Address,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if failure != nil {
+			return &NeighborhoodsResponse{Success: false, Message: failure.Message}, nil
+		}
+		center = resolved.Location
+	}
+
+	points := []maps.LatLng{center}
+	for _, bearing := range []float64{0, 90, 180, 270} {
+		points = append(points, offsetPoint(center, bearing, radius))
+	}
+
+	results := make([][]maps.GeocodingResult, len(points))
+	errs := make([]error, len(points))
+	var wg sync.WaitGroup
+	for i, point := range points {
+		wg.Add(1)
+		go func(i int, point maps.LatLng) {
+			defer wg.Done()
+			results[i], errs[i] = s.reverseGeocode(ctx, point, neighborhoodResultTypes...)
+		}(i, point)
+	}
+	wg.Wait()
+
+	// The center lookup must succeed; the surrounding samples are best effort
+	if errs[0] != nil {
+		return nil, errs[0]
+	}
+
+	byName := make(map[string]Neighborhood)
+	for _, pointResults := range results {
+		for _, result := range pointResults {
+			name := neighborhoodName(result)
+			if name == "" {
+				continue
+			}
+			loc := result.Geometry.Location
+			distance := calculateDistance(center.Lat, center.Lng, loc.Lat, loc.Lng)
+			key := strings.ToLower(name)
+			if existing, ok := byName[key]; ok && existing.Distance <= distance {
+				continue
+			}
+			byName[key] = Neighborhood{
+				Name:     name,
+				Location: Location{Lat: loc.Lat, Lng: loc.Lng},
+				Distance: distance,
+			}
+		}
+	}
+
+	neighborhoods := make([]Neighborhood, 0, len(byName))
+	for _, n := range byName {
+		neighborhoods = append(neighborhoods, n)
+	}
+	sort.Slice(neighborhoods, func(i, j int) bool {
+		return neighborhoods[i].Distance < neighborhoods[j].Distance
+	})
+
+	message := fmt.Sprintf("Found %d neighborhoods within about %.0fm", len(neighborhoods), radius)
+	if len(neighborhoods) == 0 {
+		message = "No named neighborhoods found around this location"
+	}
+
+	return &NeighborhoodsResponse{
+		Success:       true,
+		Message:       message,
+		Neighborhoods: neighborhoods,
+		Location:      Location{Lat: center.Lat, Lng: center.Lng},
+	}, nil
+}
+
+// hasAddress reports whether reverse-geocode results describe a real address. Points at sea
+// or in remote wilderness come back with no results or only a plus code.
+func hasAddress(results []maps.GeocodingResult) bool {
+	for _, result := range results {
+		for _, component := range result.AddressComponents {
+			for _, typ := range component.Types {
+				if typ != "plus_code" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// neighborhoodName returns the name of the sublocality or neighborhood a result describes
+func neighborhoodName(result maps.GeocodingResult) string {
+	for _, component := range result.AddressComponents {
+		for _, typ := range component.Types {
+			if typ == "neighborhood" || strings.HasPrefix(typ, "sublocality") {
+				return component.LongName
+			}
+		}
+	}
+	return ""
+}
+
+// offsetPoint returns the point distance meters from origin along a compass bearing in degrees.
+// Uses an equirectangular approximation, accurate enough for offsets of a few kilometers.
+func offsetPoint(origin maps.LatLng, bearing, distance float64) maps.LatLng {
+	const earthRadius = 6371000 // meters
+	rad := bearing * math.Pi / 180
+	dLat := distance * math.Cos(rad) / earthRadius
+	dLng := distance * math.Sin(rad) / (earthRadius * math.Cos(origin.Lat*math.Pi/180))
+	return maps.LatLng{
+		Lat: origin.Lat + dLat*180/math.Pi,
+		Lng: origin.Lng + dLng*180/math.Pi,
+	}
+}
+
+func (s *LocationService) handleNeighborhoods(w http.ResponseWriter, r *http.Request) {
+	var req NeighborhoodsRequest
+	if err := s.decodeJSON(r.Body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid request body")
+		return
+	}
+
+	if (req.Lat == nil) != (req.Lng == nil) {
+		writeInvalidCoordinates(w, fmt.Errorf("lat and lng must be given together"))
+		return
+	}
+	if req.Lat != nil {
+		if err := validateCoordinates(*req.Lat, *req.Lng); err != nil {
+			writeInvalidCoordinates(w, err)
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	response, err := s.GetNeighborhoods(ctx, req)
+	if err != nil {
+		writeServiceError(w, err, "Failed to find neighborhoods")
+		return
+	}
+
+	writeResponse(w, r, response)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"googlemaps.github.io/maps"
+)
+
+// detailsFromResult extracts the PIN code, city, state and country of a geocoding result
+func detailsFromResult(result maps.GeocodingResult) *Details {
+	details := &Details{FormattedAddress: result.FormattedAddress}
+	for _, component := range result.AddressComponents {
+		for _, typ := range component.Types {
+			switch typ {
+			case "postal_code":
+				details.PinCode = component.LongName
+			case "locality", "administrative_area_level_2":
+				if details.City == "" {
+					details.City = strings.ToLower(component.LongName)
+				}
+			case "administrative_area_level_1":
+				details.State = component.LongName
+			case "country":
+				details.Country = component.LongName
+			}
+		}
+	}
+	return details
+}
+
+// originDetails returns the resolved address components of the search center. The
+// forward geocode usually already has them; otherwise the center is reverse-geocoded,
+// reusing results the caller already fetched when given.
+func (s *LocationService) originDetails(ctx context.Context, center *searchCenter, reverse []maps.GeocodingResult) (*Details, error) {
+	if center.Details != nil && center.Details.City != "" {
+		return center.Details, nil
+	}
+
+	if reverse == nil {
+		var err error
+		reverse, err = s.reverseGeocode(ctx, center.Location)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(reverse) == 0 {
+		return center.Details, nil
+	}
+	return detailsFromResult(reverse[0]), nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"googlemaps.github.io/maps"
+)
+
+// establishmentName returns the name of the establishment a geocode result points at,
+// e.g. "Phoenix Mall" when the user searched for the mall by name. Plain street
+// addresses have no such component and return "".
+func establishmentName(result maps.GeocodingResult) string {
+	for _, component := range result.AddressComponents {
+		for _, typ := range component.Types {
+			switch typ {
+			case "establishment", "point_of_interest", "premise":
+				return component.LongName
+			}
+		}
+	}
+	return ""
+}
+
+// normalizePlaceName lowercases a name and collapses punctuation and spacing
+// so "Phoenix Mall," and "phoenix  mall" compare equal
+func normalizePlaceName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// nameSimilarity returns a 0-1 similarity between two normalized names based on
+// Levenshtein edit distance relative to the longer name
+func nameSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+// levenshtein computes the edit distance between two rune slices
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// landmarkUID derives a best-effort, provider-independent ID for a physical place from its
+// normalized name and coordinates rounded to 3 decimals (about 100m), so the same place
+// listed by different providers gets the same ID
+func landmarkUID(name string, location Location) string {
+	key := fmt.Sprintf("%s|%.3f|%.3f", normalizePlaceName(name), location.Lat, location.Lng)
+	sum := sha256.Sum256([]byte(key))
+	return "lm_" + hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"sort"
+	"strings"
+)
+
+// defaultParamAliases maps alternate request parameter names sent by older clients to
+// the canonical ones
+var defaultParamAliases = map[string]string{
+	"pincode":     "pin_code",
+	"pin":         "pin_code",
+	"zip":         "pin_code",
+	"zipcode":     "pin_code",
+	"postal_code": "pin_code",
+	// The review sample was called a rating breakdown before it was labelled as a sample
+	"include_rating_breakdown": "include_review_sample_breakdown",
+}
+
+// parseParamAliases merges "alias=canonical" pairs from a comma-separated list over the defaults
+func parseParamAliases(value string) map[string]string {
+	aliases := make(map[string]string, len(defaultParamAliases))
+	for alias, canonical := range defaultParamAliases {
+		aliases[alias] = canonical
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		alias, canonical, ok := strings.Cut(pair, "=")
+		alias = strings.TrimSpace(alias)
+		canonical = strings.TrimSpace(canonical)
+		if !ok || alias == "" || canonical == "" || alias == canonical {
+			log.Printf("Ignoring invalid PARAM_ALIASES entry %q", pair)
+			continue
+		}
+		aliases[alias] = canonical
+	}
+	return aliases
+}
+
+// applyParamAliases renames aliased keys in fields to their canonical names. A canonical
+// key that is already present wins; among several aliases for the same field, the one
+// that sorts first wins. Aliases are always removed.
+func applyParamAliases[M ~map[string]V, V any](fields M, aliases map[string]string) {
+	names := make([]string, 0, len(aliases))
+	for alias := range aliases {
+		if _, ok := fields[alias]; ok {
+			names = append(names, alias)
+		}
+	}
+	sort.Strings(names)
+
+	for _, alias := range names {
+		canonical := aliases[alias]
+		if _, ok := fields[canonical]; !ok {
+			fields[canonical] = fields[alias]
+		}
+		delete(fields, alias)
+	}
+}
+
+// decodeJSON decodes a JSON object request body into v after normalizing aliased
+// parameter names
+func (s *LocationService) decodeJSON(body io.Reader, v any) error {
+	var fields map[string]json.RawMessage
+	if err := json.NewDecoder(body).Decode(&fields); err != nil {
+		return err
+	}
+	applyParamAliases(fields, s.config.ParamAliases)
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// PDF page layout in points (1/72 inch), A4 portrait
+const (
+	pdfPageWidth   = 595
+	pdfPageHeight  = 842
+	pdfMargin      = 50
+	pdfLineHeight  = 14
+	pdfMaxLineRune = 95 // longer lines are truncated to stay within the margins
+)
+
+// pdfLine is one line of text in the report
+type pdfLine struct {
+	text string
+	bold bool
+	size int
+	gap  int // extra space before the line
+}
+
+// toPDF renders a printable landmark sheet: a heading, the search location, then each
+// landmark's name, rating, distance and address. It writes a minimal PDF 1.4 document
+// by hand using the built-in Helvetica fonts, flowing onto extra pages as needed.
+// Characters those fonts can't show are printed as "?", with a note saying so at the end.
+func toPDF(response *LandmarksResponse) []byte {
+	lines := []pdfLine{
+		{text: "Nearby landmarks", bold: true, size: 18},
+		{text: fmt.Sprintf("Around %.5f, %.5f", response.Location.Lat, response.Location.Lng), size: 10, gap: 4},
+		{text: "Data from Google Maps, fetched " + response.GeneratedAt.UTC().Format(time.RFC1123), size: 10},
+	}
+	unit := response.Unit
+	if unit == "" {
+		unit = UnitMeters
+	}
+	for i, landmark := range response.Landmarks {
+		lines = append(lines,
+			pdfLine{text: fmt.Sprintf("%d. %s", i+1, landmark.Name), bold: true, size: 12, gap: 12},
+			pdfLine{text: fmt.Sprintf("Rating %.1f (%d reviews), %g %s away", landmark.Rating, landmark.UserRatings, landmark.Distance, unit), size: 10},
+			pdfLine{text: landmark.Address, size: 10},
+		)
+	}
+	if slices.ContainsFunc(lines, func(line pdfLine) bool { return !pdfCanPrint(line.text) }) {
+		lines = append(lines, pdfLine{
+			text: "Characters shown as ? can't be printed here; search with language=en for Latin-script names.",
+			size: 8,
+			gap:  12,
+		})
+	}
+
+	// Lay the lines out into page content streams
+	var pages []string
+	var content strings.Builder
+	y := pdfPageHeight - pdfMargin
+	for _, line := range lines {
+		height := line.gap + max(line.size+4, pdfLineHeight)
+		if y-height < pdfMargin && content.Len() > 0 {
+			pages = append(pages, content.String())
+			content.Reset()
+			y = pdfPageHeight - pdfMargin
+		}
+		y -= height
+		font := "F1"
+		if line.bold {
+			font = "F2"
+		}
+		fmt.Fprintf(&content, "BT /%s %d Tf %d %d Td (%s) Tj ET\n", font, line.size, pdfMargin, y, pdfEscape(line.text))
+	}
+	pages = append(pages, content.String())
+
+	// Objects: 1 catalog, 2 page tree, 3-4 fonts, then a page and its content per page
+	var objects []string
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	objects = append(objects,
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+	)
+	for i, stream := range pages {
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+				pdfPageWidth, pdfPageHeight, 6+2*i),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(stream), stream),
+		)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buf.Bytes()
+}
+
+// winAnsiExtras are the characters WinAnsiEncoding places in 0x80-0x9F, where Latin-1 has
+// control codes; the rest of Latin-1 maps to itself
+var winAnsiExtras = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87, 'ˆ': 0x88,
+	'‰': 0x89, 'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E, '‘': 0x91, '’': 0x92, '“': 0x93,
+	'”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98, '™': 0x99, 'š': 0x9A, '›': 0x9B,
+	'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// pdfSubstitutes spell out common characters WinAnsiEncoding lacks
+var pdfSubstitutes = map[rune]string{
+	'₹':      "Rs ",
+	'\u2010': "-", // hyphen
+	'\u2011': "-", // non-breaking hyphen
+	'\u2009': " ", // thin space
+	'\u202F': " ", // narrow no-break space
+	'\u0964': ".", // Devanagari danda, the full stop of Hindi and Marathi
+}
+
+// winAnsiByte returns r's code in WinAnsiEncoding, or false if Helvetica can't show it
+func winAnsiByte(r rune) (byte, bool) {
+	switch {
+	case r < 0x20:
+		return ' ', true
+	case r < 0x7F, r >= 0xA0 && r <= 0xFF:
+		return byte(r), true
+	}
+	b, ok := winAnsiExtras[r]
+	return b, ok
+}
+
+// pdfCanPrint reports whether every character of text can be shown without a "?"
+func pdfCanPrint(text string) bool {
+	for _, r := range text {
+		if _, ok := winAnsiByte(r); !ok && pdfSubstitutes[r] == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// pdfEscape makes text safe for a PDF literal string in WinAnsi encoding: it escapes
+// delimiters, encodes characters above ASCII as octal escapes, replaces characters
+// WinAnsi lacks and truncates overly long lines
+func pdfEscape(text string) string {
+	var out strings.Builder
+	count := 0
+	for _, r := range text {
+		if count == pdfMaxLineRune {
+			out.WriteString("...")
+			break
+		}
+		count++
+		if substitute, ok := pdfSubstitutes[r]; ok {
+			out.WriteString(substitute)
+			continue
+		}
+		b, ok := winAnsiByte(r)
+		switch {
+		case !ok:
+			out.WriteByte('?')
+		case b == '(' || b == ')' || b == '\\':
+			out.WriteByte('\\')
+			out.WriteByte(b)
+		case b < 0x80:
+			out.WriteByte(b)
+		default:
+			fmt.Fprintf(&out, "\\%03o", b)
+		}
+	}
+	return out.String()
+}
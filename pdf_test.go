@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// parsedPDF is what parsePDF reads back from a document written by toPDF
+type parsedPDF struct {
+	pages int
+	lines []string
+}
+
+var (
+	startXrefPattern = regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`)
+	xrefPattern      = regexp.MustCompile(`^xref\n0 (\d+)\n`)
+	lengthPattern    = regexp.MustCompile(`/Length (\d+) >>\nstream\n`)
+	pageCountPattern = regexp.MustCompile(`/Count (\d+)`)
+)
+
+// parsePDF checks the structure of a document written by toPDF (header, cross-reference
+// offsets, stream lengths) and decodes the text of every Tj operator, in order
+func parsePDF(t *testing.T, data []byte) parsedPDF {
+	t.Helper()
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4\n")) {
+		t.Fatalf("missing PDF header: %q", data[:min(len(data), 20)])
+	}
+	m := startXrefPattern.FindSubmatch(data)
+	if m == nil {
+		t.Fatal("missing startxref trailer")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	m = xrefPattern.FindSubmatch(data[xref:])
+	if m == nil {
+		t.Fatalf("startxref %d doesn't point at an xref table", xref)
+	}
+	size, _ := strconv.Atoi(string(m[1]))
+	entries := data[xref+len(m[0]):]
+
+	var parsed parsedPDF
+	for i := 1; i < size; i++ {
+		entry := string(entries[20*i : 20*i+20])
+		offset, err := strconv.Atoi(entry[:10])
+		if err != nil || !strings.HasSuffix(entry, " 00000 n \n") {
+			t.Fatalf("bad xref entry %d: %q", i, entry)
+		}
+		header := fmt.Sprintf("%d 0 obj\n", i)
+		if !bytes.HasPrefix(data[offset:], []byte(header)) {
+			t.Fatalf("xref entry %d points at %q", i, data[offset:offset+20])
+		}
+		object := data[offset+len(header):]
+		object = object[:bytes.Index(object, []byte("\nendobj\n"))]
+
+		if bytes.Contains(object, []byte("/Type /Pages")) {
+			count := pageCountPattern.FindSubmatch(object)
+			parsed.pages, _ = strconv.Atoi(string(count[1]))
+		}
+		if m := lengthPattern.FindSubmatchIndex(object); m != nil {
+			length, _ := strconv.Atoi(string(object[m[2]:m[3]]))
+			stream := object[m[1]:]
+			if !bytes.Equal(stream[length:], []byte("endstream")) {
+				t.Fatalf("object %d: /Length %d doesn't end at endstream", i, length)
+			}
+			parsed.lines = append(parsed.lines, pdfStrings(t, stream[:length])...)
+		}
+	}
+	return parsed
+}
+
+// pdfStrings decodes the WinAnsi literal strings in a content stream
+func pdfStrings(t *testing.T, stream []byte) []string {
+	t.Helper()
+	fromWinAnsi := make(map[byte]rune)
+	for r, b := range winAnsiExtras {
+		fromWinAnsi[b] = r
+	}
+
+	var lines []string
+	for i := 0; i < len(stream); i++ {
+		if stream[i] != '(' {
+			continue
+		}
+		var text []rune
+		for i++; stream[i] != ')'; i++ {
+			b := stream[i]
+			if b == '\\' {
+				i++
+				switch c := stream[i]; {
+				case c >= '0' && c <= '7':
+					n, err := strconv.ParseUint(string(stream[i:i+3]), 8, 8)
+					if err != nil {
+						t.Fatalf("bad octal escape %q", stream[i:i+3])
+					}
+					b = byte(n)
+					i += 2
+				case c == '(' || c == ')' || c == '\\':
+					b = c
+				default:
+					t.Fatalf("unexpected escape \\%c", c)
+				}
+			} else if b >= 0x80 {
+				t.Fatalf("raw byte %#x in a literal string", b)
+			}
+			if r, ok := fromWinAnsi[b]; ok {
+				text = append(text, r)
+			} else {
+				text = append(text, rune(b))
+			}
+		}
+		lines = append(lines, string(text))
+	}
+	return lines
+}
+
+func TestToPDF(t *testing.T) {
+	response := &LandmarksResponse{
+		Success:     true,
+		Location:    Location{Lat: 26.4499, Lng: 80.3319},
+		GeneratedAt: time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC),
+		Landmarks: []Landmark{
+			{Name: `Café (Old) \ Bar`, Rating: 4.5, UserRatings: 120, Distance: 350, Address: "Mall Road – “Civil Lines”"},
+			{Name: "Shop ₹99", Rating: 4.0, UserRatings: 10, Distance: 1200, Address: "Naveen Market"},
+		},
+	}
+	want := []string{
+		"Nearby landmarks",
+		"Around 26.44990, 80.33190",
+		"Data from Google Maps, fetched Sun, 01 Mar 2026 09:30:00 UTC",
+		`1. Café (Old) \ Bar`,
+		"Rating 4.5 (120 reviews), 350 m away",
+		"Mall Road – “Civil Lines”",
+		"2. Shop Rs 99",
+		"Rating 4.0 (10 reviews), 1200 m away",
+		"Naveen Market",
+	}
+
+	parsed := parsePDF(t, toPDF(response))
+	if parsed.pages != 1 {
+		t.Errorf("pages = %d, want 1", parsed.pages)
+	}
+	if strings.Join(parsed.lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("lines =\n%s\nwant\n%s", strings.Join(parsed.lines, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestToPDFUnprintableCharacters(t *testing.T) {
+	response := &LandmarksResponse{
+		Success:   true,
+		Landmarks: []Landmark{{Name: "कानपुर", Address: "Kanpur"}},
+	}
+	lines := parsePDF(t, toPDF(response)).lines
+	if lines[3] != "1. ??????" {
+		t.Errorf("name line = %q, want one ? per character", lines[3])
+	}
+	if last := lines[len(lines)-1]; !strings.Contains(last, "language=en") {
+		t.Errorf("last line = %q, want a note about the unprintable characters", last)
+	}
+}
+
+func TestToPDFPages(t *testing.T) {
+	response := &LandmarksResponse{Success: true}
+	for i := 0; i < 40; i++ {
+		response.Landmarks = append(response.Landmarks, Landmark{Name: fmt.Sprintf("Place %d", i+1), Address: "Road"})
+	}
+	parsed := parsePDF(t, toPDF(response))
+	if parsed.pages < 2 {
+		t.Errorf("pages = %d, want the landmarks to flow onto more pages", parsed.pages)
+	}
+	if got, want := len(parsed.lines), 3+3*40; got != want {
+		t.Errorf("got %d lines, want %d", got, want)
+	}
+}
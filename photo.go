@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"googlemaps.github.io/maps"
+)
+
+// ErrCodePhotoNotFound is returned with 404 when Google has no image for a photo reference
+const ErrCodePhotoNotFound = "PHOTO_NOT_FOUND"
+
+// Photo proxy limits
+const (
+	maxPhotoWidth        = 1600             // largest maxwidth Google's Places Photo API accepts
+	maxPhotoRefLength    = 2000             // longer photo references are rejected unseen
+	maxPhotoBytes        = 5 << 20          // images beyond this are not proxied
+	photoCacheTTL        = 10 * time.Minute // how long fetched images are reused
+	photoCacheMaxEntries = 200
+)
+
+// errPhotoNotFound is returned by fetchPhoto when Google answers without an image
+var errPhotoNotFound = errors.New("photo not found")
+
+// photoData is a fetched place photo
+type photoData struct {
+	ContentType string
+	Data        []byte
+}
+
+// photoProxyURL is the service-relative URL of a photo through the /api/photo proxy
+func photoProxyURL(reference string, maxWidth int) string {
+	query := url.Values{}
+	query.Set("ref", reference)
+	query.Set("maxwidth", strconv.Itoa(maxWidth))
+	return "/api/photo?" + query.Encode()
+}
+
+// fetchPhoto downloads a place photo through the Maps client, so the API key stays on the
+// server. Results are cached for photoCacheTTL per reference and width.
+func (s *LocationService) fetchPhoto(ctx context.Context, reference string, maxWidth int) (*photoData, error) {
+	key := reference + "|" + strconv.Itoa(maxWidth)
+	if photo, ok := s.photoCache.Get(key); ok {
+		return photo, nil
+	}
+
+	resp, err := s.mapsClient.PlacePhoto(ctx, &maps.PlacePhotoRequest{
+		PhotoReference: reference,
+		MaxWidth:       uint(maxWidth),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("place photo failed: %w", checkAPIEnabled(placesAPI, err))
+	}
+	defer resp.Data.Close()
+
+	// Google answers an unknown or expired reference with an error page, not an image
+	if !strings.HasPrefix(resp.ContentType, "image/") {
+		return nil, errPhotoNotFound
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Data, maxPhotoBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading place photo failed: %w", err)
+	}
+	if len(data) > maxPhotoBytes {
+		return nil, fmt.Errorf("place photo exceeds %d bytes", maxPhotoBytes)
+	}
+
+	photo := &photoData{ContentType: resp.ContentType, Data: data}
+	s.photoCache.Set(key, photo)
+	return photo, nil
+}
+
+func (s *LocationService) handlePhoto(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	reference := strings.TrimSpace(query.Get("ref"))
+	if reference == "" || len(reference) > maxPhotoRefLength {
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid ref: a photo reference is required")
+		return
+	}
+
+	maxWidth := s.config.PhotoMaxWidth
+	if value := query.Get("maxwidth"); value != "" {
+		var err error
+		maxWidth, err = strconv.Atoi(value)
+		if err != nil || maxWidth < 1 || maxWidth > maxPhotoWidth {
+			writeError(w, http.StatusBadRequest, ErrCodeInvalidRequest,
+				fmt.Sprintf("Invalid maxwidth: must be between 1 and %d", maxPhotoWidth))
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	photo, err := s.fetchPhoto(ctx, reference, maxWidth)
+	if errors.Is(err, errPhotoNotFound) {
+		writeError(w, http.StatusNotFound, ErrCodePhotoNotFound, "No photo found for this reference")
+		return
+	}
+	if err != nil {
+		writeServiceError(w, err, "Failed to get photo")
+		return
+	}
+
+	w.Header().Set("Content-Type", photo.ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(photo.Data)))
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(photoCacheTTL.Seconds())))
+	w.Write(photo.Data)
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// maxPinBatchSize caps the number of PIN codes in one batch validation request
+const maxPinBatchSize = 100
+
+// pinBatchConcurrency is how many PIN codes of a batch are validated at once
+const pinBatchConcurrency = 5
+
+type ValidatePinCodeBatchRequest struct {
+	Items []ValidatePinCodeRequest `json:"items"`
+}
+
+type ValidatePinCodeBatchResponse struct {
+	// Results are in the same order as the request's items
+	Results []*ValidationResponse `json:"results"`
+	// APICalls counts the live Maps calls made for the whole batch, only with the
+	// X-Include-API-Calls header
+	APICalls *APICallCount `json:"api_calls,omitempty"`
+}
+
+// ValidatePinCodeBatch validates each item like handleValidatePinCode, at most
+// pinBatchConcurrency at a time. An item whose lookup fails, or that can't start before ctx
+// is done, gets a FailureLookupError result instead of failing the batch.
+func (s *LocationService) ValidatePinCodeBatch(ctx context.Context, items []ValidatePinCodeRequest) []*ValidationResponse {
+	results := make([]*ValidationResponse, len(items))
+	sem := make(chan struct{}, pinBatchConcurrency)
+	var wg sync.WaitGroup
+
+	for i, item := range items {
+		wg.Add(1)
+		go func(i int, item ValidatePinCodeRequest) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				results[i] = lookupFailure(fmt.Errorf("not validated: %w", ctx.Err()))
+				return
+			}
+
+			itemCtx, trace := s.debugContext(ctx)
+			response, err := s.validatePinCode(itemCtx, item)
+			if err != nil {
+				results[i] = lookupFailure(err)
+				return
+			}
+			if trace != nil {
+				response.GeocodeQueries = trace.Queries()
+			}
+			results[i] = response
+		}(i, item)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// lookupFailure is the batch result for an item that couldn't be validated
+func lookupFailure(err error) *ValidationResponse {
+	return &ValidationResponse{
+		Valid:         false,
+		Message:       fmt.Sprintf("Validation failed: %v", err),
+		FailureReason: FailureLookupError,
+	}
+}
+
+func (s *LocationService) handleValidatePinCodeBatch(w http.ResponseWriter, r *http.Request) {
+	var req ValidatePinCodeBatchRequest
+	if err := s.decodeJSON(r.Body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid request body")
+		return
+	}
+	if len(req.Items) == 0 || len(req.Items) > maxPinBatchSize {
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidRequest,
+			fmt.Sprintf("A batch must contain between 1 and %d items", maxPinBatchSize))
+		return
+	}
+
+	// Cancelled when the client disconnects, so the remaining items are skipped
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+	ctx, calls := s.apiCallContext(ctx, r.Header.Get("X-Include-API-Calls"))
+
+	response := &ValidatePinCodeBatchResponse{Results: s.ValidatePinCodeBatch(ctx, req.Items)}
+	if calls != nil {
+		response.APICalls = calls.Snapshot()
+	}
+
+	writeResponse(w, r, response)
+}
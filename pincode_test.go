@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"googlemaps.github.io/maps"
+)
+
+// fakePostalGeocode is a geocode result for a postal code in city, at testCenter
+func fakePostalGeocode(pinCode, city, country string) []maps.GeocodingResult {
+	result := maps.GeocodingResult{
+		FormattedAddress: pinCode + ", " + city + ", " + country,
+		AddressComponents: []maps.AddressComponent{
+			{LongName: pinCode, Types: []string{"postal_code"}},
+			{LongName: city, Types: []string{"locality"}},
+			{LongName: country, Types: []string{"country"}},
+		},
+	}
+	result.Geometry.Location = testCenter
+	result.Geometry.LocationType = string(maps.GeocodeAccuracyApproximate)
+	return []maps.GeocodingResult{result}
+}
+
+func TestGetNearbyLandmarksPostalCodeCountry(t *testing.T) {
+	tests := []struct {
+		name        string
+		pinCode     string
+		city        string
+		country     string
+		countryName string
+		// wantComponent is the country component expected on every geocode ("" for none)
+		wantComponent string
+	}{
+		{"India by default", "208001", "Kanpur", "", "India", ""},
+		{"India", "208001", "Kanpur", "IN", "India", "IN"},
+		{"UK", "SW1A 1AA", "London", "UK", "United Kingdom", "GB"},
+		{"Canada", "K1A 0B1", "Ottawa", "ca", "Canada", "CA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeMapsClient{
+				geocodes: map[string][]maps.GeocodingResult{
+					tt.pinCode:                  fakePostalGeocode(tt.pinCode, tt.city, tt.countryName),
+					tt.pinCode + ", " + tt.city: fakePostalGeocode(tt.pinCode, tt.city, tt.countryName),
+				},
+				places: []maps.PlacesSearchResult{fakePlace("Museum", 4.5, 1000, 200)},
+			}
+			response, err := newTestService(t, client).GetNearbyLandmarks(context.Background(), GetLandmarksRequest{
+				PinCode: tt.pinCode,
+				City:    tt.city,
+				Country: tt.country,
+			})
+			if err != nil {
+				t.Fatalf("GetNearbyLandmarks: %v", err)
+			}
+			if !response.Success || len(response.Landmarks) != 1 {
+				t.Fatalf("got success=%v, %d landmarks (%q), want the museum", response.Success, len(response.Landmarks), response.Message)
+			}
+			if len(client.geocodeRequests) != 2 {
+				t.Fatalf("geocode calls = %d, want the PIN code and PIN code + city", len(client.geocodeRequests))
+			}
+			for _, req := range client.geocodeRequests {
+				if got := req.Components[maps.ComponentCountry]; got != tt.wantComponent {
+					t.Errorf("geocode %q country component = %q, want %q", req.Address, got, tt.wantComponent)
+				}
+			}
+		})
+	}
+}
+
+func TestGetNearbyLandmarksForeignPostalCodeNeedsCountry(t *testing.T) {
+	client := &fakeMapsClient{}
+	response, err := newTestService(t, client).GetNearbyLandmarks(context.Background(), GetLandmarksRequest{
+		PinCode: "SW1A 1AA",
+		City:    "London",
+	})
+	if err != nil {
+		t.Fatalf("GetNearbyLandmarks: %v", err)
+	}
+	// Checked against the default Indian PIN format, without any Geocoding call
+	if response.Success || response.Message != "PIN code must be 6 digits" {
+		t.Errorf("got success=%v %q, want the Indian format failure", response.Success, response.Message)
+	}
+	if got := client.callCount("geocode"); got != 0 {
+		t.Errorf("geocode calls = %d, want 0", got)
+	}
+}
+
+func TestValidatePinCodeRejectsMalformedBeforeGeocoding(t *testing.T) {
+	tests := []struct {
+		pinCode     string
+		wantFailure string
+		wantGeocode bool
+	}{
+		{"110001", "", true},
+		{"abc", FailureInvalidFormat, false},
+		{"00123", FailureInvalidFormat, false},   // too short, and no postal zone 0
+		{"1234567", FailureInvalidFormat, false}, // too long
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pinCode, func(t *testing.T) {
+			client := &fakeMapsClient{geocodes: map[string][]maps.GeocodingResult{
+				"110001": fakePostalGeocode("110001", "New Delhi", "India"),
+			}}
+			response, err := newTestService(t, client).ValidatePinCodeWithCity(context.Background(), tt.pinCode, "New Delhi", "")
+			if err != nil {
+				t.Fatalf("ValidatePinCodeWithCity: %v", err)
+			}
+			if response.FailureReason != tt.wantFailure {
+				t.Errorf("failure reason = %q (%s), want %q", response.FailureReason, response.Message, tt.wantFailure)
+			}
+			if response.Valid != (tt.wantFailure == "") {
+				t.Errorf("valid = %v, want %v", response.Valid, tt.wantFailure == "")
+			}
+			if got := client.callCount("geocode") > 0; got != tt.wantGeocode {
+				t.Errorf("geocoded = %v, want %v", got, tt.wantGeocode)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"log"
+	"regexp"
+	"strings"
+)
+
+// postalCodeFormat is the expected shape of postal codes in one country
+type postalCodeFormat struct {
+	pattern *regexp.Regexp
+	message string
+}
+
+// postalCodeFormats are the postal-code formats checked before geocoding, keyed by ISO
+// country code. Codes for countries not listed here are sent to Google unchecked.
+var postalCodeFormats = map[string]postalCodeFormat{
+	// Indian PIN codes are six digits; the first is a postal zone from 1 to 9
+	"IN": {regexp.MustCompile(`^[1-9][0-9]{5}$`), "PIN code must be 6 digits"},
+	// UK postcodes are an outward code (area, district) and an inward code, e.g. "SW1A 1AA"
+	"GB": {regexp.MustCompile(`(?i)^[A-Z]{1,2}[0-9][A-Z0-9]? ?[0-9][A-Z]{2}$`), "Postcode must look like SW1A 1AA"},
+	// Canadian postal codes alternate letter and digit, e.g. "K1A 0B1"; D, F, I, O, Q and U
+	// are never used, nor W and Z as the first letter
+	"CA": {regexp.MustCompile(`(?i)^[ABCEGHJ-NPRSTVXY][0-9][ABCEGHJ-NPRSTV-Z] ?[0-9][ABCEGHJ-NPRSTV-Z][0-9]$`), "Postal code must look like K1A 0B1"},
+}
+
+// countryCodePattern matches an ISO 3166-1 alpha-2 country code once upper-cased
+var countryCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// normalizeCountry upper-cases a requested country code. "UK" is accepted for Great
+// Britain, since that's what most people type; Google only knows "GB".
+func normalizeCountry(value string) string {
+	country := strings.ToUpper(strings.TrimSpace(value))
+	if country == "UK" {
+		return "GB"
+	}
+	return country
+}
+
+// parsePostalCodeCountry returns the upper-cased country code, defaulting to India
+func parsePostalCodeCountry(value string) string {
+	country := strings.ToUpper(strings.TrimSpace(value))
+	if country == "" {
+		return "IN"
+	}
+	if _, ok := postalCodeFormats[country]; !ok {
+		log.Printf("No postal code format for POSTAL_CODE_COUNTRY=%q; PIN codes won't be pre-checked", value)
+	}
+	return country
+}
+
+// checkPinFormat rejects PIN codes that can't be valid in country (a normalized code, or
+// empty for Config.PostalCodeCountry), so obviously malformed input never costs a Geocoding
+// call. Returns nil when the format is fine or the country has no known format.
+func (s *LocationService) checkPinFormat(pinCode, country string) *ValidationResponse {
+	if country == "" {
+		country = s.config.PostalCodeCountry
+	} else if !countryCodePattern.MatchString(country) {
+		return &ValidationResponse{
+			Valid:         false,
+			Message:       "Country must be a two-letter ISO code such as IN, GB or CA",
+			FailureReason: FailureInvalidFormat,
+		}
+	}
+	format, ok := postalCodeFormats[country]
+	if !ok || format.pattern.MatchString(pinCode) {
+		return nil
+	}
+	return &ValidationResponse{
+		Valid:         false,
+		Message:       format.message,
+		FailureReason: FailureInvalidFormat,
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"googlemaps.github.io/maps"
+)
+
+// ErrCodePlaceNotFound is returned with 404 when Google doesn't know a place ID
+const ErrCodePlaceNotFound = "PLACE_NOT_FOUND"
+
+// placeFields is the Place Details field mask for the place detail endpoint
+var placeFields = []maps.PlaceDetailsFieldMask{
+	maps.PlaceDetailsFieldMaskPlaceID,
+	maps.PlaceDetailsFieldMaskName,
+	maps.PlaceDetailsFieldMaskFormattedAddress,
+	maps.PlaceDetailsFieldMaskGeometryLocation,
+	maps.PlaceDetailsFieldMaskTypes,
+	maps.PlaceDetailsFieldMaskFormattedPhoneNumber,
+	maps.PlaceDetailsFieldMaskWebsite,
+	maps.PlaceDetailsFieldMaskRatings,
+	maps.PlaceDetailsFieldMaskUserRatingsTotal,
+	maps.PlaceDetailsFieldMaskOpeningHours,
+	maps.PlaceDetailsFieldMaskPhotos,
+}
+
+// errPlaceNotFound is returned by GetPlace when Google reports no place for the ID
+var errPlaceNotFound = errors.New("place not found")
+
+// errInvalidPlaceID is returned by GetPlace when Google rejects the ID as malformed
+var errInvalidPlaceID = errors.New("invalid place ID")
+
+// PlaceResponse is everything a landmark detail page needs about one place
+type PlaceResponse struct {
+	PlaceID          string   `json:"place_id"`
+	Name             string   `json:"name"`
+	FormattedAddress string   `json:"formatted_address"`
+	Location         Location `json:"location"`
+	Types            []string `json:"types"`
+	PhoneNumber      string   `json:"phone_number,omitempty"`
+	Website          string   `json:"website,omitempty"`
+	Rating           float32  `json:"rating"`
+	UserRatings      int      `json:"user_ratings_total"`
+	// OpeningHours has one line per weekday, e.g. "Monday: 9:00 AM – 9:00 PM"
+	OpeningHours []string `json:"opening_hours,omitempty"`
+	OpenNow      *bool    `json:"open_now,omitempty"`
+	Photos       []Photo  `json:"photos,omitempty"`
+}
+
+// Photo is a place photo reference. URL loads it through the /api/photo proxy at
+// Config.PhotoMaxWidth; Attributions are HTML snippets Google requires to be shown alongside
+// the photo.
+type Photo struct {
+	Reference    string   `json:"reference"`
+	URL          string   `json:"url"`
+	Width        int      `json:"width"`
+	Height       int      `json:"height"`
+	Attributions []string `json:"attributions,omitempty"`
+}
+
+// GetPlace fetches Place Details for a place ID. It returns errPlaceNotFound or
+// errInvalidPlaceID when Google doesn't accept the ID.
+func (s *LocationService) GetPlace(ctx context.Context, placeID string) (*PlaceResponse, error) {
+	details, err := withRetry(ctx, s, func() (maps.PlaceDetailsResult, error) {
+		return s.mapsClient.PlaceDetails(ctx, &maps.PlaceDetailsRequest{
+			PlaceID:  placeID,
+			Fields:   placeFields,
+			Language: s.languageFrom(ctx),
+		})
+	})
+	if err != nil {
+		switch mapsStatus(err) {
+		case "NOT_FOUND", "ZERO_RESULTS":
+			return nil, errPlaceNotFound
+		case "INVALID_REQUEST":
+			return nil, errInvalidPlaceID
+		}
+		return nil, fmt.Errorf("place details failed: %w", checkAPIEnabled(placesAPI, err))
+	}
+
+	place := &PlaceResponse{
+		PlaceID:          details.PlaceID,
+		Name:             details.Name,
+		FormattedAddress: details.FormattedAddress,
+		Location:         Location{Lat: details.Geometry.Location.Lat, Lng: details.Geometry.Location.Lng},
+		Types:            details.Types,
+		PhoneNumber:      details.FormattedPhoneNumber,
+		Website:          details.Website,
+		Rating:           details.Rating,
+		UserRatings:      details.UserRatingsTotal,
+	}
+	if details.OpeningHours != nil {
+		place.OpeningHours = details.OpeningHours.WeekdayText
+		place.OpenNow = details.OpeningHours.OpenNow
+	}
+	for _, photo := range details.Photos {
+		place.Photos = append(place.Photos, Photo{
+			Reference:    photo.PhotoReference,
+			URL:          photoProxyURL(photo.PhotoReference, s.config.PhotoMaxWidth),
+			Width:        photo.Width,
+			Height:       photo.Height,
+			Attributions: photo.HTMLAttributions,
+		})
+	}
+	return place, nil
+}
+
+func (s *LocationService) handleGetPlace(w http.ResponseWriter, r *http.Request) {
+	placeID := strings.TrimSpace(mux.Vars(r)["place_id"])
+	if placeID == "" {
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "Missing place ID")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	ctx = withLanguage(ctx, s.resolveLanguage(r.URL.Query().Get("language"), mapsLanguages))
+
+	response, err := s.GetPlace(ctx, placeID)
+	switch {
+	case errors.Is(err, errPlaceNotFound):
+		writeError(w, http.StatusNotFound, ErrCodePlaceNotFound, fmt.Sprintf("No place found for ID %q", placeID))
+		return
+	case errors.Is(err, errInvalidPlaceID):
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidRequest, fmt.Sprintf("Invalid place ID %q", placeID))
+		return
+	case err != nil:
+		writeServiceError(w, err, "Failed to get place")
+		return
+	}
+
+	writeResponse(w, r, response)
+}
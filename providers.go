@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"googlemaps.github.io/maps"
+)
+
+// placeProvider is a source of nearby places. Results use Google's result shape so places
+// from every provider are filtered and scored the same way. NearbySearch also reports how
+// many result pages it fetched.
+type placeProvider interface {
+	Name() string
+	NearbySearch(ctx context.Context, req *maps.NearbySearchRequest) ([]maps.PlacesSearchResult, int, error)
+}
+
+// placeProviderFactories builds each provider that may appear in NEARBY_PROVIDERS
+var placeProviderFactories = map[string]func(s *LocationService) placeProvider{
+	"google": func(s *LocationService) placeProvider { return googleProvider{s} },
+}
+
+// googleProvider searches Google Places Nearby Search
+type googleProvider struct {
+	s *LocationService
+}
+
+func (p googleProvider) Name() string { return "google" }
+
+// nextPageTokenDelay is how long Google needs before a next_page_token becomes valid
+const nextPageTokenDelay = 2 * time.Second
+
+// NearbySearch follows next_page_token through up to Config.NearbyMaxPages pages of 20
+// results. It stops early when the token delay would outlast ctx's deadline, and keeps the
+// pages it has if a later page fails.
+func (p googleProvider) NearbySearch(ctx context.Context, req *maps.NearbySearchRequest) ([]maps.PlacesSearchResult, int, error) {
+	response, err := p.s.nearbySearch(ctx, req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("nearby search failed: %w", checkAPIEnabled(placesAPI, err))
+	}
+	places := response.Results
+	pages := 1
+
+	for token := response.NextPageToken; token != "" && pages < p.s.config.NearbyMaxPages; pages++ {
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < 2*nextPageTokenDelay {
+			break
+		}
+		select {
+		case <-time.After(nextPageTokenDelay):
+		case <-ctx.Done():
+			return places, pages, nil
+		}
+
+		response, err = p.s.nearbySearch(ctx, &maps.NearbySearchRequest{PageToken: token})
+		if err != nil {
+			log.Printf("Nearby search page %d failed, keeping %d results: %v", pages+1, len(places), err)
+			break
+		}
+		places = append(places, response.Results...)
+		token = response.NextPageToken
+	}
+	return places, pages, nil
+}
+
+// Provider chain modes, selected with NEARBY_PROVIDER_MODE
+const (
+	ProviderModeFirstSuccess = "first_success" // use the first provider that returns places
+	ProviderModeMerge        = "merge"         // query every provider and merge by UID
+)
+
+// parseProviderChain returns the provider names in a comma-separated list, in order,
+// dropping unknown and repeated names. Defaults to Google only.
+func parseProviderChain(value string) []string {
+	var chain []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		if _, ok := placeProviderFactories[name]; !ok {
+			log.Printf("Unknown nearby search provider %q in NEARBY_PROVIDERS, skipping", name)
+			continue
+		}
+		seen[name] = true
+		chain = append(chain, name)
+	}
+	if len(chain) == 0 {
+		return []string{"google"}
+	}
+	return chain
+}
+
+// parseProviderMode returns the mode named by value, defaulting to first_success
+func parseProviderMode(value string) string {
+	switch mode := strings.ToLower(strings.TrimSpace(value)); mode {
+	case "":
+		return ProviderModeFirstSuccess
+	case ProviderModeFirstSuccess, ProviderModeMerge:
+		return mode
+	default:
+		log.Printf("Invalid NEARBY_PROVIDER_MODE=%q, using default %s", value, ProviderModeFirstSuccess)
+		return ProviderModeFirstSuccess
+	}
+}
+
+// newPlaceProviders builds the providers named in the chain, in order
+func newPlaceProviders(s *LocationService, chain []string) []placeProvider {
+	providers := make([]placeProvider, 0, len(chain))
+	for _, name := range chain {
+		providers = append(providers, placeProviderFactories[name](s))
+	}
+	return providers
+}
+
+// searchNearby runs one nearby search through the provider chain. In first_success mode
+// providers are tried in order until one returns places; in merge mode all are queried and
+// a place whose UID an earlier provider already returned is dropped, keeping the earlier
+// provider's copy. Places from one provider aren't matched by UID, as distinct places with
+// the same name stand close together (two branches of a bank); searchLandmarks merges
+// them by place ID. A failing provider
+// is skipped while another succeeds; if all fail, the first error is returned. pages is the
+// total result pages fetched across providers.
+func (s *LocationService) searchNearby(ctx context.Context, req *maps.NearbySearchRequest) (places []maps.PlacesSearchResult, pages int, err error) {
+	var firstErr error
+	succeeded := false
+	// UIDs of places returned by earlier providers, for merge mode
+	earlier := make(map[string]bool)
+
+	for _, provider := range s.placeProviders {
+		results, fetched, err := provider.NearbySearch(ctx, req)
+		pages += fetched
+		if err != nil {
+			if len(s.placeProviders) > 1 {
+				log.Printf("Nearby search via %s failed: %v", provider.Name(), err)
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		succeeded = true
+
+		if s.config.NearbyProviderMode != ProviderModeMerge {
+			places = append(places, results...)
+		} else {
+			var uids []string
+			for _, place := range results {
+				uid := landmarkUID(place.Name, Location{Lat: place.Geometry.Location.Lat, Lng: place.Geometry.Location.Lng})
+				if earlier[uid] {
+					continue
+				}
+				uids = append(uids, uid)
+				places = append(places, place)
+			}
+			for _, uid := range uids {
+				earlier[uid] = true
+			}
+		}
+		if s.config.NearbyProviderMode == ProviderModeFirstSuccess && len(places) > 0 {
+			break
+		}
+	}
+
+	if !succeeded {
+		return nil, pages, firstErr
+	}
+	return places, pages, nil
+}
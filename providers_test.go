@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"googlemaps.github.io/maps"
+)
+
+// stubProvider is a second place provider returning canned places
+type stubProvider struct {
+	places []maps.PlacesSearchResult
+}
+
+func (p stubProvider) Name() string { return "stub" }
+
+func (p stubProvider) NearbySearch(ctx context.Context, req *maps.NearbySearchRequest) ([]maps.PlacesSearchResult, int, error) {
+	return p.places, 1, nil
+}
+
+// sameNamePlaces are two branches of one bank 30m apart, whose UIDs match
+func sameNamePlaces() []maps.PlacesSearchResult {
+	first := fakePlace("SBI ATM", 4.0, 100, 300)
+	first.PlaceID = "id-SBI-1"
+	second := fakePlace("SBI ATM", 3.8, 40, 330)
+	second.PlaceID = "id-SBI-2"
+	return []maps.PlacesSearchResult{first, second}
+}
+
+func TestSearchNearbyKeepsSameNamePlacesFromOneProvider(t *testing.T) {
+	got := searchNames(t, sameNamePlaces(), GetLandmarksRequest{})
+	if want := []string{"SBI ATM", "SBI ATM"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("landmarks = %v, want %v", got, want)
+	}
+}
+
+func TestSearchNearbyMergeDropsOtherProvidersCopies(t *testing.T) {
+	client := newAddressClient(sameNamePlaces())
+	config := loadConfig()
+	config.NearbyProviderMode = ProviderModeMerge
+	service := newLocationService(client, config)
+
+	// The stub finds Google's first ATM again under its own ID, and a place Google missed
+	copied := fakePlace("SBI ATM", 4.0, 100, 300)
+	copied.PlaceID = "stub-SBI"
+	service.placeProviders = append(service.placeProviders, stubProvider{[]maps.PlacesSearchResult{
+		copied,
+		fakePlace("Museum", 4.5, 1000, 200),
+	}})
+
+	places, pages, err := service.searchNearby(context.Background(), &maps.NearbySearchRequest{})
+	if err != nil {
+		t.Fatalf("searchNearby: %v", err)
+	}
+	var ids []string
+	for _, place := range places {
+		ids = append(ids, place.PlaceID)
+	}
+	if want := []string{"id-SBI-1", "id-SBI-2", "id-Museum"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("place IDs = %v, want %v", ids, want)
+	}
+	if pages != 2 {
+		t.Errorf("pages = %d, want 2", pages)
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// landmarksRequestFromQuery builds a GetLandmarksRequest from URL query parameters using
+// the same names as the JSON body. List fields such as types take repeated or
+// comma-separated values; object fields such as score_weights take JSON text. Numbers must
+// be finite.
+func landmarksRequestFromQuery(q url.Values) (GetLandmarksRequest, error) {
+	var req GetLandmarksRequest
+	err := decodeQuery(q, reflect.ValueOf(&req).Elem())
+	return req, err
+}
+
+// decodeQuery sets the fields of the struct v from the query parameters named by their
+// JSON tags. Parameters without a matching field are ignored, like unknown JSON keys.
+func decodeQuery(q url.Values, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if !field.IsExported() || name == "" || name == "-" {
+			continue
+		}
+		values, ok := q[name]
+		if !ok || len(values) == 0 {
+			continue
+		}
+		if err := setQueryField(v.Field(i), values); err != nil {
+			return fmt.Errorf("invalid %s %q", name, strings.Join(values, ","))
+		}
+	}
+	return nil
+}
+
+// setQueryField parses query values into one field. Scalars use the first value.
+func setQueryField(field reflect.Value, values []string) error {
+	value := strings.TrimSpace(values[0])
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(n))
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		// ParseFloat accepts "NaN" and "Inf", which JSON bodies can't carry and no
+		// numeric parameter means
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return fmt.Errorf("%q is not a finite number", value)
+		}
+		field.SetFloat(f)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported list type %s", field.Type())
+		}
+		var items []string
+		for _, value := range values {
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+		}
+		field.Set(reflect.ValueOf(items))
+	default:
+		return json.Unmarshal([]byte(value), field.Addr().Interface())
+	}
+	return nil
+}
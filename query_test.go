@@ -0,0 +1,67 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestLandmarksRequestFromQuery(t *testing.T) {
+	q, _ := url.ParseQuery(`pin_code=208001&city=Kanpur&radius=500&include_details=true&min_rating=4.5` +
+		`&limit=7&types=restaurant,cafe&types=atm&category_priority={"atm":0.5}`)
+	got, err := landmarksRequestFromQuery(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := GetLandmarksRequest{
+		PinCode:          "208001",
+		City:             "Kanpur",
+		Radius:           500,
+		IncludeDetails:   true,
+		MinRating:        4.5,
+		Limit:            7,
+		Types:            []string{"restaurant", "cafe", "atm"},
+		CategoryPriority: map[string]float64{"atm": 0.5},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("request = %+v, want %+v", got, want)
+	}
+}
+
+func TestLandmarksRequestFromQueryRejects(t *testing.T) {
+	for _, query := range []string{
+		"radius=NaN",
+		"radius=nan",
+		"radius=Inf",
+		"radius=-Inf",
+		"radius=+Infinity",
+		"min_rating=NaN",
+		"unrated_score=inf",
+		"radius=1e400",
+		"radius=wide",
+		"limit=1.5",
+		"include_details=maybe",
+		"category_priority={atm}",
+	} {
+		q, _ := url.ParseQuery(query)
+		if _, err := landmarksRequestFromQuery(q); err == nil {
+			t.Errorf("%s: accepted", query)
+		}
+	}
+}
+
+func TestGetLandmarksRejectsNonFiniteRadius(t *testing.T) {
+	client := newAddressClient(nil)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/api/get-landmarks?address=1+Mall+Road&radius=NaN", nil)
+	newTestService(t, client).handleGetLandmarks(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", w.Code)
+	}
+	if client.callCount("nearby") != 0 {
+		t.Error("searched with a NaN radius")
+	}
+}
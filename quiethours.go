@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// quietWindow is a daily time window, e.g. 22:00-06:00, that may wrap past midnight
+type quietWindow struct {
+	start, end time.Duration // offsets from midnight
+	location   *time.Location
+}
+
+// parseQuietHours parses a "HH:MM-HH:MM" window interpreted in the named IANA timezone.
+// Returns nil, disabling quiet hours, when value is empty or invalid.
+func parseQuietHours(value, timezone string) *quietWindow {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		log.Printf("Invalid QUIET_HOURS_TZ=%q, quiet hours disabled: %v", timezone, err)
+		return nil
+	}
+	from, to, ok := strings.Cut(value, "-")
+	start, startErr := parseClock(from)
+	end, endErr := parseClock(to)
+	if !ok || startErr != nil || endErr != nil || start == end {
+		log.Printf("Invalid QUIET_HOURS=%q, expected HH:MM-HH:MM; quiet hours disabled", value)
+		return nil
+	}
+	return &quietWindow{start: start, end: end, location: location}
+}
+
+// parseClock parses "HH:MM" into an offset from midnight
+func parseClock(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q: %w", value, err)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// contains reports whether t falls inside the window, in the window's timezone
+func (w *quietWindow) contains(t time.Time) bool {
+	t = t.In(w.location)
+	offset := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	if w.start < w.end {
+		return offset >= w.start && offset < w.end
+	}
+	// The window wraps past midnight
+	return offset >= w.start || offset < w.end
+}
+
+// inQuietHours reports whether the configured quiet-hours window is in effect now
+func (s *LocationService) inQuietHours() bool {
+	return s.config.QuietHours != nil && s.config.QuietHours.contains(time.Now())
+}
+
+// cacheTTLFactor stretches cache TTLs by Config.QuietHoursTTLFactor during quiet hours
+func (s *LocationService) cacheTTLFactor() float64 {
+	if s.inQuietHours() {
+		return s.config.QuietHoursTTLFactor
+	}
+	return 1
+}
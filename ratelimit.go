@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCodeRateLimited is returned with 429 when a client exceeds RATE_LIMIT_PER_MINUTE
+const ErrCodeRateLimited = "RATE_LIMITED"
+
+// rateLimitSweepInterval is how often idle buckets are dropped from a rateLimiter
+const rateLimitSweepInterval = time.Minute
+
+// tokenBucket holds one client's remaining requests, refilled continuously
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// rateLimiter is a per-client token bucket: each client may burst up to perMinute requests,
+// refilled at perMinute requests per minute
+type rateLimiter struct {
+	mu        sync.Mutex
+	perMinute float64
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func newRateLimiter(perMinute int) *rateLimiter {
+	return &rateLimiter{
+		perMinute: float64(perMinute),
+		buckets:   make(map[string]*tokenBucket),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+// allow takes a token from key's bucket. When the bucket is empty it returns false and how
+// long until the next token is available.
+func (l *rateLimiter) allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Sub(l.lastSweep) >= rateLimitSweepInterval {
+		l.sweep(now)
+	}
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: l.perMinute, last: now}
+		l.buckets[key] = bucket
+	}
+	bucket.tokens = l.refilled(bucket, now)
+	bucket.last = now
+
+	if bucket.tokens < 1 {
+		wait := time.Duration((1 - bucket.tokens) / l.perMinute * float64(time.Minute))
+		return false, wait
+	}
+	bucket.tokens--
+	return true, 0
+}
+
+// refilled returns the bucket's tokens as of now, capped at a full bucket
+func (l *rateLimiter) refilled(bucket *tokenBucket, now time.Time) float64 {
+	elapsed := now.Sub(bucket.last).Minutes()
+	return math.Min(l.perMinute, bucket.tokens+elapsed*l.perMinute)
+}
+
+// sweep drops buckets that have refilled completely; they behave exactly like new ones.
+// Callers must hold l.mu.
+func (l *rateLimiter) sweep(now time.Time) {
+	for key, bucket := range l.buckets {
+		if l.refilled(bucket, now) >= l.perMinute {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+// middleware throttles requests under /api/ per client IP, answering 429 with Retry-After
+// once a client's bucket is empty. Health checks, admin endpoints and static files are not
+// throttled. A nil limiter lets everything through.
+func (l *rateLimiter) middleware(trustForwardedFor bool, next http.Handler) http.Handler {
+	if l == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/api/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ok, wait := l.allow(clientIP(r, trustForwardedFor))
+		if !ok {
+			seconds := int(math.Ceil(wait.Seconds()))
+			w.Header().Set("Retry-After", strconv.Itoa(seconds))
+			writeError(w, http.StatusTooManyRequests, ErrCodeRateLimited,
+				fmt.Sprintf("Too many requests; retry in %d seconds", seconds))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// clientIP identifies the client for rate limiting. With trustForwardedFor, the last
+// address in X-Forwarded-For is used: that is the one the proxy in front of the service
+// appended, while earlier ones come from the client and can be spoofed. Only enable it behind
+// a proxy that appends to the header, since clients can otherwise set it to dodge the limit.
+func clientIP(r *http.Request, trustForwardedFor bool) string {
+	if values := r.Header.Values("X-Forwarded-For"); trustForwardedFor && len(values) > 0 {
+		forwarded := values[len(values)-1]
+		if ip := strings.TrimSpace(forwarded[strings.LastIndex(forwarded, ",")+1:]); ip != "" {
+			return ip
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
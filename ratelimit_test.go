@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// fakeClock is a settable time source for rateLimiter.now
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) advance(d time.Duration) { c.now = c.now.Add(d) }
+
+// newTestRateLimiter returns a limiter of perMinute requests running on a fake clock
+func newTestRateLimiter(perMinute int) (*rateLimiter, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)}
+	limiter := newRateLimiter(perMinute)
+	limiter.now = clock.Now
+	limiter.lastSweep = clock.now
+	return limiter, clock
+}
+
+func TestRateLimiterAllow(t *testing.T) {
+	limiter, clock := newTestRateLimiter(2)
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := limiter.allow("1.2.3.4"); !ok {
+			t.Fatalf("request %d refused within the burst", i+1)
+		}
+	}
+	ok, wait := limiter.allow("1.2.3.4")
+	if ok {
+		t.Fatal("third request allowed, want it refused")
+	}
+	if wait != 30*time.Second {
+		t.Errorf("wait = %v, want 30s for one token at 2 per minute", wait)
+	}
+	if ok, _ := limiter.allow("5.6.7.8"); !ok {
+		t.Error("another client was refused, want a bucket per client")
+	}
+
+	clock.advance(29 * time.Second)
+	if ok, _ := limiter.allow("1.2.3.4"); ok {
+		t.Error("allowed before a token refilled")
+	}
+	clock.advance(time.Second)
+	if ok, _ := limiter.allow("1.2.3.4"); !ok {
+		t.Error("refused once a token refilled")
+	}
+}
+
+func TestRateLimiterMiddleware(t *testing.T) {
+	limiter, clock := newTestRateLimiter(1)
+	handler := limiter.middleware(false, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	serve := func(path string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		r.RemoteAddr = "1.2.3.4:5678"
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	if w := serve("/api/get-landmarks"); w.Code != http.StatusNoContent {
+		t.Fatalf("first request: status = %d, want %d", w.Code, http.StatusNoContent)
+	}
+	w := serve("/api/get-landmarks")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("second request: status = %d, want %d", w.Code, http.StatusTooManyRequests)
+	}
+	if got := w.Header().Get("Retry-After"); got != "60" {
+		t.Errorf("Retry-After = %q, want 60", got)
+	}
+	var body ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || body.Code != ErrCodeRateLimited {
+		t.Errorf("body = %s, want an ErrorResponse with code %s", w.Body, ErrCodeRateLimited)
+	}
+	if w := serve("/health"); w.Code != http.StatusNoContent {
+		t.Errorf("health check: status = %d, want it not throttled", w.Code)
+	}
+
+	clock.advance(time.Minute)
+	if w := serve("/api/get-landmarks"); w.Code != http.StatusNoContent {
+		t.Errorf("after the window: status = %d, want %d", w.Code, http.StatusNoContent)
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		name      string
+		forwarded []string
+		trust     bool
+		want      string
+	}{
+		{"remote address", nil, false, "10.0.0.1"},
+		{"forwarded header not trusted", []string{"1.2.3.4"}, false, "10.0.0.1"},
+		{"single forwarded address", []string{"1.2.3.4"}, true, "1.2.3.4"},
+		{"spoofed leading addresses", []string{"6.6.6.6, 1.2.3.4"}, true, "1.2.3.4"},
+		{"several header lines", []string{"6.6.6.6", "7.7.7.7, 1.2.3.4"}, true, "1.2.3.4"},
+		{"empty last entry", []string{"6.6.6.6, "}, true, "10.0.0.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/get-landmarks", nil)
+			r.RemoteAddr = "10.0.0.1:5678"
+			for _, value := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", value)
+			}
+			if got := clientIP(r, tt.trust); got != tt.want {
+				t.Errorf("clientIP = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"googlemaps.github.io/maps"
+)
+
+const (
+	// readinessCacheTTL is how long a readiness result is reused, so probes don't burn quota
+	readinessCacheTTL = 30 * time.Second
+	// readinessTimeout bounds the probe's Maps call
+	readinessTimeout = 3 * time.Second
+)
+
+// readinessCheck remembers the last Maps connectivity probe
+type readinessCheck struct {
+	mu        sync.Mutex
+	checkedAt time.Time
+	err       error
+}
+
+// checkReadiness geocodes a known PIN code straight through the Maps client, bypassing the
+// geocode cache, and reports any error. Results are reused for readinessCacheTTL; concurrent
+// callers wait for a single probe.
+func (s *LocationService) checkReadiness() error {
+	s.readiness.mu.Lock()
+	defer s.readiness.mu.Unlock()
+
+	if time.Since(s.readiness.checkedAt) < readinessCacheTTL {
+		return s.readiness.err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), readinessTimeout)
+	defer cancel()
+
+	_, err := s.mapsClient.Geocode(ctx, &maps.GeocodingRequest{Address: "110001"})
+	if err != nil {
+		err = fmt.Errorf("geocoding probe failed: %w", checkAPIEnabled(geocodingAPI, err))
+	}
+	s.readiness.checkedAt = time.Now()
+	s.readiness.err = err
+	return err
+}
+
+// handleReadyz reports whether the service can reach Google Maps with its API key: 200 when
+// a probe geocode succeeds, 503 otherwise. /health stays a pure liveness check.
+func (s *LocationService) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := s.checkReadiness(); err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode(map[string]string{"status": "unavailable", "error": err.Error()})
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]string{"status": "ready"})
+}
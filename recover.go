@@ -0,0 +1,35 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"runtime/debug"
+)
+
+// recoverMiddleware turns a panic anywhere in the handler chain into a 500 INTERNAL_ERROR
+// response and logs the panic with its stack, so one bad request can't take the server
+// down. It wraps every other middleware, including requestIDMiddleware, so it finds the
+// request ID in the response header that middleware sets. If the response had already
+// started, a clean error can't be sent any more; the connection is then aborted, as
+// net/http does, so the client doesn't mistake the truncated body for a complete one.
+func recoverMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		recorder := &statusRecorder{ResponseWriter: w}
+		defer func() {
+			err := recover()
+			if err == nil {
+				return
+			}
+			if err == http.ErrAbortHandler {
+				panic(err)
+			}
+
+			log.Printf("[%s] Panic serving %s %s: %v\n%s", w.Header().Get(requestIDHeader), r.Method, r.URL.Path, err, debug.Stack())
+			if recorder.status != 0 {
+				panic(http.ErrAbortHandler)
+			}
+			writeError(w, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+		}()
+		next.ServeHTTP(recorder, r)
+	})
+}
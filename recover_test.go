@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+// captureLog redirects the standard logger to a buffer for the rest of the test
+func captureLog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+	return &buf
+}
+
+// panicChain serves handler on /api/boom behind the same middleware as main
+func panicChain(handler http.HandlerFunc) (http.Handler, *serviceMetrics) {
+	router := mux.NewRouter()
+	router.HandleFunc("/api/boom", handler)
+	metrics := newServiceMetrics()
+	chain := recoverMiddleware(requestIDMiddleware(metrics.middleware(router,
+		gzipMiddleware(loggingMiddleware(corsMiddleware(Config{}, router))))))
+	return chain, metrics
+}
+
+func TestRecoverMiddlewarePanic(t *testing.T) {
+	logs := captureLog(t)
+	chain, metrics := panicChain(func(w http.ResponseWriter, r *http.Request) {
+		var landmarks []Landmark
+		_ = landmarks[3].Name // deliberate index out of range
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/api/boom", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	r.Header.Set(requestIDHeader, "req-42")
+	w := httptest.NewRecorder()
+	chain.ServeHTTP(w, r)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", w.Code)
+	}
+	if got := w.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", got)
+	}
+	if got := w.Header().Get(requestIDHeader); got != "req-42" {
+		t.Errorf("%s = %q, want req-42", requestIDHeader, got)
+	}
+	var body ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body %q is not JSON: %v", w.Body.String(), err)
+	}
+	if !body.Error || body.Code != ErrCodeInternal {
+		t.Errorf("body = %+v, want an %s error", body, ErrCodeInternal)
+	}
+
+	if !strings.Contains(logs.String(), "[req-42] Panic serving GET /api/boom: runtime error: index out of range") {
+		t.Errorf("log doesn't report the panic with the request ID:\n%s", logs)
+	}
+	if !strings.Contains(logs.String(), "recover_test.go") {
+		t.Errorf("log doesn't include the stack:\n%s", logs)
+	}
+	if got := metrics.requests["/api/boom|GET|500"]; got != 1 {
+		t.Errorf("500s counted for /api/boom = %d, want 1", got)
+	}
+}
+
+func TestRecoverMiddlewarePanicAfterWrite(t *testing.T) {
+	captureLog(t)
+	chain, _ := panicChain(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("partial"))
+		panic("boom")
+	})
+
+	defer func() {
+		if err := recover(); err != http.ErrAbortHandler {
+			t.Errorf("recovered %v, want http.ErrAbortHandler", err)
+		}
+	}()
+	chain.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/boom", nil))
+	t.Error("a panic after the response started wasn't turned into an abort")
+}
+
+func TestRecoverMiddlewarePassesThrough(t *testing.T) {
+	captureLog(t)
+	chain, _ := panicChain(func(w http.ResponseWriter, r *http.Request) {
+		writeResponse(w, r, map[string]bool{"ok": true})
+	})
+
+	w := httptest.NewRecorder()
+	chain.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/boom", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"ok":true`) {
+		t.Errorf("got %d %q, want the handler's response", w.Code, w.Body.String())
+	}
+}
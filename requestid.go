@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+
+	"github.com/google/uuid"
+)
+
+// requestIDHeader carries the request ID in both directions
+const requestIDHeader = "X-Request-ID"
+
+// validRequestID matches incoming request IDs worth keeping, e.g. from a load balancer;
+// anything else is replaced so arbitrary client input never reaches the logs
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// requestIDKey is the context key for the request ID
+type requestIDKey struct{}
+
+// withRequestID returns a context carrying the request ID
+func withRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// requestIDFrom returns the context's request ID, or "" if it has none
+func requestIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// requestIDMiddleware gives every request an ID, reusing a well-formed X-Request-ID from
+// the client or a proxy and generating a UUID otherwise. The ID is attached to the request
+// context and echoed in the X-Request-ID response header.
+func requestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = uuid.New().String()
+		}
+		w.Header().Set(requestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(withRequestID(r.Context(), id)))
+	})
+}
+
+// logRequest logs a message prefixed with the context's request ID, so all log lines of
+// one request can be found together
+func logRequest(ctx context.Context, format string, args ...any) {
+	log.Printf("[%s] %s", requestIDFrom(ctx), fmt.Sprintf(format, args...))
+}
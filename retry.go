@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"googlemaps.github.io/maps"
+)
+
+// retryDelays are the waits before each retry of a transient Maps failure
+var retryDelays = []time.Duration{200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond}
+
+// defaultRetryableStatuses are the Maps API statuses treated as transient
+var defaultRetryableStatuses = []string{"OVER_QUERY_LIMIT", "UNKNOWN_ERROR"}
+
+// parseStatusList parses a comma-separated list of Maps API statuses, returning def when empty
+func parseStatusList(value string, def []string) map[string]bool {
+	statuses := make(map[string]bool)
+	for _, status := range strings.Split(value, ",") {
+		if status = strings.ToUpper(strings.TrimSpace(status)); status != "" {
+			statuses[status] = true
+		}
+	}
+	if len(statuses) == 0 {
+		for _, status := range def {
+			statuses[status] = true
+		}
+	}
+	return statuses
+}
+
+// mapsStatus extracts the API status from a Maps client error of the form
+// "maps: OVER_QUERY_LIMIT - message", or "" if err isn't one
+func mapsStatus(err error) string {
+	rest, ok := strings.CutPrefix(err.Error(), "maps: ")
+	if !ok {
+		return ""
+	}
+	status, _, _ := strings.Cut(rest, " - ")
+	return strings.TrimSpace(status)
+}
+
+// isRetryable reports whether a failed Maps call is worth retrying: network and timeout
+// errors always are, as are non-JSON replies (Google's 5xx error pages), and API statuses
+// listed in Config.RetryableStatuses. Cancellation of the caller's context never is.
+func (s *LocationService) isRetryable(err error) bool {
+	// context.DeadlineExceeded also satisfies net.Error, so rule it out first
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return true
+	}
+
+	return s.config.RetryableStatuses[mapsStatus(err)]
+}
+
+// withRetry runs call, retrying transient failures after each of retryDelays. It gives up
+// early, returning the last error, when the wait would outlast ctx's deadline or ctx ends.
+func withRetry[T any](ctx context.Context, s *LocationService, call func() (T, error)) (T, error) {
+	result, err := call()
+	for _, delay := range retryDelays {
+		if !s.isRetryable(err) {
+			break
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			break
+		}
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return result, err
+		}
+		result, err = call()
+	}
+	return result, err
+}
+
+// nearbySearch runs a Nearby Search in the context's language, retrying transient failures
+func (s *LocationService) nearbySearch(ctx context.Context, req *maps.NearbySearchRequest) (maps.PlacesSearchResponse, error) {
+	req.Language = s.languageFrom(ctx)
+	return withRetry(ctx, s, func() (maps.PlacesSearchResponse, error) {
+		return s.mapsClient.NearbySearch(ctx, req)
+	})
+}
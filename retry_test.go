@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"googlemaps.github.io/maps"
+)
+
+// shortRetryDelays swaps in millisecond retry delays for the rest of the test
+func shortRetryDelays(t *testing.T) {
+	t.Helper()
+	saved := retryDelays
+	retryDelays = []time.Duration{time.Millisecond, time.Millisecond, time.Millisecond}
+	t.Cleanup(func() { retryDelays = saved })
+}
+
+func TestWithRetry(t *testing.T) {
+	overQueryLimit := errors.New("maps: OVER_QUERY_LIMIT - slow down")
+	tests := []struct {
+		name      string
+		failures  []error
+		wantCalls int
+		wantErr   bool
+	}{
+		{"succeeds first time", nil, 1, false},
+		{"fails twice then succeeds", []error{overQueryLimit, overQueryLimit}, 3, false},
+		{"gives up after every retry", []error{overQueryLimit, overQueryLimit, overQueryLimit, overQueryLimit}, 4, true},
+		{"zero results is not retried", []error{errors.New("maps: ZERO_RESULTS - ")}, 1, true},
+		{"invalid request is not retried", []error{errors.New("maps: INVALID_REQUEST - bad location")}, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shortRetryDelays(t)
+			client := &fakeMapsClient{
+				places:   []maps.PlacesSearchResult{fakePlace("Fort", 4.0, 100, 500)},
+				failures: map[string][]error{"nearby": tt.failures},
+			}
+			response, err := newTestService(t, client).nearbySearch(context.Background(), &maps.NearbySearchRequest{})
+
+			if got := client.callCount("nearby"); got != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", got, tt.wantCalls)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if err == nil && len(response.Results) != 1 {
+				t.Errorf("got %d results, want 1", len(response.Results))
+			}
+		})
+	}
+}
+
+func TestWithRetryStopsBeforeDeadline(t *testing.T) {
+	client := &fakeMapsClient{failures: map[string][]error{
+		"nearby": {errors.New("maps: UNKNOWN_ERROR - ")},
+	}}
+	// Less time left than the first retry delay
+	ctx, cancel := context.WithTimeout(context.Background(), retryDelays[0]/2)
+	defer cancel()
+
+	start := time.Now()
+	_, err := newTestService(t, client).nearbySearch(ctx, &maps.NearbySearchRequest{})
+	if mapsStatus(err) != "UNKNOWN_ERROR" {
+		t.Errorf("err = %v, want the UNKNOWN_ERROR failure", err)
+	}
+	if got := client.callCount("nearby"); got != 1 {
+		t.Errorf("calls = %d, want 1", got)
+	}
+	if elapsed := time.Since(start); elapsed >= retryDelays[0]/2 {
+		t.Errorf("took %v, want it to give up without waiting out the deadline", elapsed)
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"googlemaps.github.io/maps"
+)
+
+type ReverseGeocodeRequest struct {
+	Lat *float64 `json:"lat"`
+	Lng *float64 `json:"lng"`
+}
+
+type ReverseGeocodeResponse struct {
+	Success bool     `json:"success"`
+	Message string   `json:"message"`
+	Details *Details `json:"details,omitempty"`
+}
+
+// ReverseGeocode resolves coordinates to a PIN code, city, state and country. Of the
+// results Google returns, the first with a postal code is used, since callers typically
+// go on to use the PIN code.
+func (s *LocationService) ReverseGeocode(ctx context.Context, point maps.LatLng) (*ReverseGeocodeResponse, error) {
+	results, err := s.reverseGeocode(ctx, point)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return &ReverseGeocodeResponse{
+			Success: false,
+			Message: "No address found at these coordinates",
+		}, nil
+	}
+
+	result := results[0]
+	for _, candidate := range results {
+		if componentName(candidate, "postal_code") != "" {
+			result = candidate
+			break
+		}
+	}
+	if err := s.checkServiceCountry(result); err != nil {
+		return nil, err
+	}
+
+	return &ReverseGeocodeResponse{
+		Success: true,
+		Message: "Location resolved",
+		Details: detailsFromResult(result),
+	}, nil
+}
+
+func (s *LocationService) handleReverseGeocode(w http.ResponseWriter, r *http.Request) {
+	var req ReverseGeocodeRequest
+	if err := s.decodeJSON(r.Body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid request body")
+		return
+	}
+
+	if req.Lat == nil || req.Lng == nil {
+		writeInvalidCoordinates(w, fmt.Errorf("lat and lng are required"))
+		return
+	}
+	if err := validateCoordinates(*req.Lat, *req.Lng); err != nil {
+		writeInvalidCoordinates(w, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	response, err := s.ReverseGeocode(ctx, maps.LatLng{Lat: *req.Lat, Lng: *req.Lng})
+	if err != nil {
+		writeServiceError(w, err, "Reverse geocoding failed")
+		return
+	}
+
+	writeResponse(w, r, response)
+}
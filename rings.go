@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"googlemaps.github.io/maps"
+)
+
+// defaultRings are the ring boundaries in meters used when none are given
+var defaultRings = []float64{250, 500, 1000}
+
+// maxRings caps the number of rings per request
+const maxRings = 10
+
+type RingCountsRequest struct {
+	PinCode string `json:"pin_code,omitempty"`
+	City    string `json:"city,omitempty"`
+	Address string `json:"address,omitempty"`
+	// Rings are ascending outer boundaries in meters; the last one is the search radius
+	Rings []float64 `json:"rings,omitempty"`
+}
+
+type RingCountsResponse struct {
+	Success  bool        `json:"success"`
+	Message  string      `json:"message"`
+	Rings    []RingCount `json:"rings"`
+	Location Location    `json:"location"`
+}
+
+type RingCount struct {
+	InnerRadius   float64 `json:"inner_radius"`
+	OuterRadius   float64 `json:"outer_radius"`
+	Count         int     `json:"count"`
+	AverageRating float64 `json:"average_rating"` // over rated places only, 0 if none
+}
+
+// validateRings checks that ring boundaries are at least 1m, strictly ascending and within
+// limits. Below 1m the search radius, a whole number of meters, would round to 0.
+func validateRings(rings []float64) error {
+	if len(rings) > maxRings {
+		return fmt.Errorf("at most %d rings are allowed", maxRings)
+	}
+	prev := 0.0
+	for _, r := range rings {
+		if r < 1 {
+			return errors.New("rings must be at least 1 meter")
+		}
+		if r <= prev {
+			return errors.New("rings must be in ascending order")
+		}
+		prev = r
+	}
+	if prev > 50000 {
+		return errors.New("outermost ring cannot exceed 50000 meters")
+	}
+	return nil
+}
+
+// GetRingCounts counts nearby places in concentric distance rings around the search center,
+// without returning the places themselves
+func (s *LocationService) GetRingCounts(ctx context.Context, req RingCountsRequest) (*RingCountsResponse, error) {
+	rings := req.Rings
+	if len(rings) == 0 {
+		rings = defaultRings
+	}
+
+	center, failure, err := s.resolveSearchCenter(ctx, GetLandmarksRequest{
+		PinCode: req.PinCode,
+		City:    req.City,
+		Address: req.Address,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if failure != nil {
+		return &RingCountsResponse{Success: false, Message: failure.Message}, nil
+	}
+	location := center.Location
+
+	nearbyResults, err := s.nearbySearch(ctx, &maps.NearbySearchRequest{
+		Location: &location,
+		Radius:   uint(math.Ceil(rings[len(rings)-1])),
+		Type:     maps.PlaceType("point_of_interest"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("nearby search failed: %w", checkAPIEnabled(placesAPI, err))
+	}
+
+	counts := make([]RingCount, len(rings))
+	ratingSums := make([]float64, len(rings))
+	ratedCounts := make([]int, len(rings))
+	inner := 0.0
+	for i, outer := range rings {
+		counts[i] = RingCount{InnerRadius: inner, OuterRadius: outer}
+		inner = outer
+	}
+
+	for _, place := range nearbyResults.Results {
+		distance := calculateDistance(
+			location.Lat, location.Lng,
+			place.Geometry.Location.Lat, place.Geometry.Location.Lng,
+		)
+		for i, outer := range rings {
+			if distance <= outer {
+				counts[i].Count++
+				if place.UserRatingsTotal > 0 {
+					ratingSums[i] += float64(place.Rating)
+					ratedCounts[i]++
+				}
+				break
+			}
+		}
+	}
+
+	total := 0
+	for i := range counts {
+		total += counts[i].Count
+		if ratedCounts[i] > 0 {
+			counts[i].AverageRating = ratingSums[i] / float64(ratedCounts[i])
+		}
+	}
+
+	return &RingCountsResponse{
+		Success: true,
+		Message: fmt.Sprintf("Counted %d places within %.0fm", total, rings[len(rings)-1]),
+		Rings:   counts,
+		Location: Location{
+			Lat: location.Lat,
+			Lng: location.Lng,
+		},
+	}, nil
+}
+
+func (s *LocationService) handleRingCounts(w http.ResponseWriter, r *http.Request) {
+	var req RingCountsRequest
+	if err := s.decodeJSON(r.Body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid request body")
+		return
+	}
+
+	if err := validateRings(req.Rings); err != nil {
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid rings: "+err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	response, err := s.GetRingCounts(ctx, req)
+	if err != nil {
+		writeServiceError(w, err, "Failed to count places")
+		return
+	}
+
+	writeResponse(w, r, response)
+}
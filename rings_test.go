@@ -0,0 +1,31 @@
+package main
+
+import "testing"
+
+func TestValidateRings(t *testing.T) {
+	tests := []struct {
+		name    string
+		rings   []float64
+		wantErr bool
+	}{
+		{"none", nil, false},
+		{"defaults", defaultRings, false},
+		{"one meter", []float64{1}, false},
+		{"fractional", []float64{1.5, 250.5}, false},
+		{"largest radius", []float64{50000}, false},
+		{"under a meter", []float64{0.5}, true},
+		{"zero", []float64{0, 500}, true},
+		{"negative", []float64{-100, 500}, true},
+		{"descending", []float64{1000, 500}, true},
+		{"repeated", []float64{500, 500}, true},
+		{"radius too large", []float64{1000, 50001}, true},
+		{"too many", []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateRings(tt.rings); (err != nil) != tt.wantErr {
+				t.Errorf("validateRings(%v) = %v, want error %v", tt.rings, err, tt.wantErr)
+			}
+		})
+	}
+}
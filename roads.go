@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"googlemaps.github.io/maps"
+)
+
+// roadsAPI is the Google Maps Platform API used for road-distance enrichment
+const roadsAPI = "Roads API"
+
+// enrichWithRoadDistance sets each landmark's RoadDistance to the meters between the
+// landmark and the nearest road, using a single Nearest Roads call for all landmarks.
+// Landmarks with no road nearby (the API snaps within roughly 50m) are left unset.
+func (s *LocationService) enrichWithRoadDistance(ctx context.Context, landmarks []Landmark) {
+	if len(landmarks) == 0 {
+		return
+	}
+
+	points := make([]maps.LatLng, len(landmarks))
+	for i, landmark := range landmarks {
+		points[i] = maps.LatLng{Lat: landmark.Location.Lat, Lng: landmark.Location.Lng}
+	}
+
+	resp, err := s.mapsClient.NearestRoads(ctx, &maps.NearestRoadsRequest{Points: points})
+	if err != nil {
+		log.Printf("Nearest roads lookup failed: %v", checkAPIEnabled(roadsAPI, err))
+		return
+	}
+
+	// A point can snap to several roads (e.g. both directions); keep the closest
+	for _, snapped := range resp.SnappedPoints {
+		if snapped.OriginalIndex == nil || *snapped.OriginalIndex < 0 || *snapped.OriginalIndex >= len(landmarks) {
+			continue
+		}
+		landmark := &landmarks[*snapped.OriginalIndex]
+		distance := calculateDistance(
+			landmark.Location.Lat, landmark.Location.Lng,
+			snapped.Location.Lat, snapped.Location.Lng,
+		)
+		if landmark.RoadDistance == nil || distance < *landmark.RoadDistance {
+			landmark.RoadDistance = &distance
+		}
+	}
+}
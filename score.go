@@ -0,0 +1,55 @@
+package main
+
+import "math"
+
+// roundTo rounds v to the given number of decimal places; negative decimals leave v unchanged
+func roundTo(v float64, decimals int) float64 {
+	if decimals < 0 {
+		return v
+	}
+	pow := math.Pow(10, float64(decimals))
+	return math.Round(v*pow) / pow
+}
+
+// ScoreConfig holds the service-wide popularity formula weights:
+//
+//	score = rating^RatingWeight × log10(reviews+1)^ReviewWeight / (1 + DistancePenalty × km)
+//
+// The defaults (all 1) give the original (rating × log10(reviews+1)) / (1 + km).
+type ScoreConfig struct {
+	// RatingWeight is the exponent on the rating; above 1 favors highly rated places
+	RatingWeight float64
+	// ReviewWeight is the exponent on the review term; above 1 favors well-reviewed places
+	ReviewWeight float64
+	// DistancePenalty is how much each kilometer from the center divides the score by;
+	// 0 ignores distance
+	DistancePenalty float64
+}
+
+// defaultScoreConfig reproduces the original popularity formula
+var defaultScoreConfig = ScoreConfig{RatingWeight: 1, ReviewWeight: 1, DistancePenalty: 1}
+
+// scoreLandmark computes the popularity score of a place with the given rating, review count
+// and distance from the center in meters
+func scoreLandmark(rating, reviews, distance float64, cfg ScoreConfig) float64 {
+	reviewScore := math.Pow(rating, cfg.RatingWeight) * math.Pow(math.Log10(reviews+1), cfg.ReviewWeight)
+	distancePenalty := 1.0 + cfg.DistancePenalty*(distance/1000.0)
+	return reviewScore / distancePenalty
+}
+
+// ScoreWeights tunes the popularity score per request; the zero value keeps the default formula
+type ScoreWeights struct {
+	// MaturityReviews is the review count at which a place gets full weight. Places with
+	// fewer reviews have their score scaled by log(1+reviews)/log(1+MaturityReviews), so
+	// just-opened listings with a handful of glowing reviews don't outrank established
+	// ones. 0 disables the penalty.
+	MaturityReviews int `json:"maturity_reviews,omitempty"`
+}
+
+// maturityFactor returns the score multiplier for a place with the given review count
+func (w ScoreWeights) maturityFactor(reviews int) float64 {
+	if w.MaturityReviews <= 0 || reviews >= w.MaturityReviews {
+		return 1
+	}
+	return math.Log1p(float64(reviews)) / math.Log1p(float64(w.MaturityReviews))
+}
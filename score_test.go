@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+
+	"googlemaps.github.io/maps"
+)
+
+// TestScoreLandmarkDefaultFormula locks down the original popularity formula,
+// (rating × log10(reviews+1)) / (1 + km), which the default weights must reproduce
+func TestScoreLandmarkDefaultFormula(t *testing.T) {
+	tests := []struct {
+		rating, reviews, distance float64
+		want                      float64
+	}{
+		{4.5, 1000, 500, 9.001302232437956},
+		{4.0, 99, 0, 8.0},
+		{3.2, 9, 2500, 0.9142857142857144},
+		{5.0, 0, 100, 0},
+		{4.8, 12000, 12000, 1.5061725916602706},
+	}
+	for _, tt := range tests {
+		got := scoreLandmark(tt.rating, tt.reviews, tt.distance, defaultScoreConfig)
+		if math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("scoreLandmark(%v, %v, %v) = %v, want %v", tt.rating, tt.reviews, tt.distance, got, tt.want)
+		}
+	}
+}
+
+func TestScoreLandmarkWeights(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  ScoreConfig
+		want float64
+	}{
+		{"squared rating, doubled distance penalty", ScoreConfig{RatingWeight: 2, ReviewWeight: 1, DistancePenalty: 2}, 30.3793950344781},
+		{"distance ignored", ScoreConfig{RatingWeight: 1, ReviewWeight: 1, DistancePenalty: 0}, 13.501953348656935},
+		{"reviews ignored", ScoreConfig{RatingWeight: 1, ReviewWeight: 0, DistancePenalty: 1}, 3},
+	}
+	for _, tt := range tests {
+		got := scoreLandmark(4.5, 1000, 500, tt.cfg)
+		if math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("%s: score = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMaturityFactor(t *testing.T) {
+	tests := []struct {
+		maturity, reviews int
+		want              float64
+	}{
+		{0, 3, 1}, // penalty off
+		{100, 100, 1},
+		{100, 5000, 1},
+		{100, 9, math.Log(10) / math.Log(101)},
+		{100, 0, 0},
+	}
+	for _, tt := range tests {
+		got := ScoreWeights{MaturityReviews: tt.maturity}.maturityFactor(tt.reviews)
+		if math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("maturityFactor(%d reviews, maturity %d) = %v, want %v", tt.reviews, tt.maturity, got, tt.want)
+		}
+	}
+}
+
+func TestMaturityPenaltyReordersLandmarks(t *testing.T) {
+	places := []maps.PlacesSearchResult{
+		fakePlace("Newcomer", 5.0, 9, 100),       // 5.0*1.000/1.1 = 4.55; ×0.50 = 2.27
+		fakePlace("Established", 4.2, 500, 2000), // 4.2*2.700/3.0 = 3.78
+		fakePlace("Landmark", 4.6, 3000, 1500),   // 4.6*3.477/2.5 = 6.40
+	}
+	tests := []struct {
+		name     string
+		maturity int
+		want     []string
+	}{
+		{"without the penalty", 0, []string{"Landmark", "Newcomer", "Established"}},
+		{"with the penalty", 100, []string{"Landmark", "Established", "Newcomer"}},
+		{"with a threshold every place meets", 5, []string{"Landmark", "Newcomer", "Established"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := searchNames(t, places, GetLandmarksRequest{ScoreWeights: ScoreWeights{MaturityReviews: tt.maturity}})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("landmarks = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreDecimals(t *testing.T) {
+	places := []maps.PlacesSearchResult{
+		fakePlace("Museum", 4.5, 1000, 200),
+		fakePlace("Fort", 4.0, 100, 500),
+	}
+	scores := func(decimals int) []float64 {
+		service := newTestService(t, newAddressClient(places))
+		service.config.ScoreDecimals = decimals
+		response, err := service.GetNearbyLandmarks(context.Background(), GetLandmarksRequest{Address: "1 Mall Road"})
+		if err != nil {
+			t.Fatalf("GetNearbyLandmarks: %v", err)
+		}
+		var scores []float64
+		for _, landmark := range response.Landmarks {
+			scores = append(scores, landmark.PopScore)
+		}
+		return scores
+	}
+
+	full := scores(-1)
+	for _, decimals := range []int{0, 2} {
+		got := scores(decimals)
+		for i := range full {
+			if want := roundTo(full[i], decimals); got[i] != want || want == full[i] {
+				t.Errorf("decimals %d: score %d = %v, want %v rounded from %v", decimals, i, got[i], want, full[i])
+			}
+		}
+	}
+}
+
+func TestScoreDecimalsConfigIsClamped(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int
+	}{
+		{"", 2},
+		{"0", 0},
+		{"3", 3},
+		{"6", 6},
+		{"-1", 0},
+		{"400", 6},
+	}
+	for _, tt := range tests {
+		t.Setenv("SCORE_DECIMALS", tt.value)
+		if got := loadConfig().ScoreDecimals; got != tt.want {
+			t.Errorf("SCORE_DECIMALS=%q: ScoreDecimals = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"googlemaps.github.io/maps"
+)
+
+type NormalizeAddressRequest struct {
+	// Address is free text; alternatively give a PIN code, optionally with a city
+	Address string `json:"address,omitempty"`
+	PinCode string `json:"pin_code,omitempty"`
+	City    string `json:"city,omitempty"`
+}
+
+type NormalizeAddressResponse struct {
+	Success bool               `json:"success"`
+	Message string             `json:"message"`
+	Address *NormalizedAddress `json:"address,omitempty"`
+}
+
+// NormalizedAddress is a geocoded address split into the parts a shipping label needs.
+// Parts Google didn't return are omitted.
+type NormalizedAddress struct {
+	House    string `json:"house,omitempty"`
+	Street   string `json:"street,omitempty"`
+	Locality string `json:"locality,omitempty"`
+	City     string `json:"city,omitempty"`
+	State    string `json:"state,omitempty"`
+	PinCode  string `json:"pin_code,omitempty"`
+	Country  string `json:"country,omitempty"`
+	// Formatted is the single-line label: house, street, locality, "city - PIN", state, country
+	Formatted string `json:"formatted"`
+}
+
+// normalizeAddress extracts label parts from a geocoding result. For each part the first
+// matching component wins, so "premise" beats "street_number" for the house and the most
+// specific sublocality is used for the locality.
+func normalizeAddress(result maps.GeocodingResult) *NormalizedAddress {
+	parts := map[string]string{}
+	partTypes := []struct {
+		part  string
+		types []string
+	}{
+		{"house", []string{"premise", "subpremise", "street_number"}},
+		{"street", []string{"route"}},
+		{"locality", []string{"sublocality_level_1", "sublocality", "neighborhood"}},
+		{"city", []string{"locality", "administrative_area_level_2"}},
+		{"state", []string{"administrative_area_level_1"}},
+		{"pin", []string{"postal_code"}},
+		{"country", []string{"country"}},
+	}
+	for _, pt := range partTypes {
+		for _, typ := range pt.types {
+			if name := componentName(result, typ); name != "" {
+				parts[pt.part] = name
+				break
+			}
+		}
+	}
+
+	address := &NormalizedAddress{
+		House:    parts["house"],
+		Street:   parts["street"],
+		Locality: parts["locality"],
+		City:     parts["city"],
+		State:    parts["state"],
+		PinCode:  parts["pin"],
+		Country:  parts["country"],
+	}
+	address.Formatted = formatIndianAddress(address)
+	return address
+}
+
+// componentName returns the long name of the first address component of the given type
+func componentName(result maps.GeocodingResult, componentType string) string {
+	for _, component := range result.AddressComponents {
+		for _, typ := range component.Types {
+			if typ == componentType {
+				return component.LongName
+			}
+		}
+	}
+	return ""
+}
+
+// formatIndianAddress joins the parts in India Post order, e.g.
+// "12, MG Road, Koramangala, Bengaluru - 560034, Karnataka, India", skipping missing parts
+func formatIndianAddress(a *NormalizedAddress) string {
+	cityLine := a.City
+	if a.PinCode != "" {
+		if cityLine != "" {
+			cityLine += " - " + a.PinCode
+		} else {
+			cityLine = a.PinCode
+		}
+	}
+
+	var parts []string
+	for _, part := range []string{a.House, a.Street, a.Locality, cityLine, a.State, a.Country} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// NormalizeAddress geocodes a free-text address or PIN code and returns it as a
+// structured, label-ready address
+func (s *LocationService) NormalizeAddress(ctx context.Context, req NormalizeAddressRequest) (*NormalizeAddressResponse, error) {
+	geocodeReq := &maps.GeocodingRequest{Address: strings.TrimSpace(req.Address)}
+	if geocodeReq.Address == "" {
+		pinCode := strings.TrimSpace(req.PinCode)
+		if pinCode == "" {
+			return &NormalizeAddressResponse{
+				Success: false,
+				Message: "Please provide an address or a PIN code",
+			}, nil
+		}
+		geocodeReq.Address = strings.Trim(pinCode+", "+strings.TrimSpace(req.City), ", ")
+		geocodeReq.Components = map[maps.Component]string{maps.ComponentPostalCode: pinCode}
+	}
+
+	results, err := s.geocode(ctx, geocodeReq)
+	if err != nil {
+		return nil, fmt.Errorf("geocoding failed: %w", checkAPIEnabled(geocodingAPI, err))
+	}
+	if len(results) == 0 {
+		return &NormalizeAddressResponse{
+			Success: false,
+			Message: "Could not find the specified address",
+		}, nil
+	}
+	if err := s.checkServiceCountry(results[0]); err != nil {
+		return nil, err
+	}
+
+	return &NormalizeAddressResponse{
+		Success: true,
+		Message: "Address normalized",
+		Address: normalizeAddress(results[0]),
+	}, nil
+}
+
+func (s *LocationService) handleNormalizeAddress(w http.ResponseWriter, r *http.Request) {
+	var req NormalizeAddressRequest
+	if err := s.decodeJSON(r.Body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid request body")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	response, err := s.NormalizeAddress(ctx, req)
+	if err != nil {
+		writeServiceError(w, err, "Failed to normalize address")
+		return
+	}
+
+	writeResponse(w, r, response)
+}
@@ -0,0 +1,59 @@
+package main
+
+import "strings"
+
+// Sort orders accepted in GetLandmarksRequest.SortBy
+const (
+	SortPopularity     = "popularity"      // highest popularity score first (default)
+	SortDistance       = "distance"        // nearest first
+	SortRating         = "rating"          // highest Google rating first
+	SortReviews        = "reviews"         // most reviews first
+	SortTravelDuration = "travel_duration" // shortest route first, per page; needs travel_mode
+)
+
+// parseSortBy normalizes a requested sort order, defaulting to SortPopularity
+func parseSortBy(sortBy string) (string, bool) {
+	sortBy = strings.ToLower(strings.TrimSpace(sortBy))
+	switch sortBy {
+	case "":
+		return SortPopularity, true
+	case SortPopularity, SortDistance, SortRating, SortReviews, SortTravelDuration:
+		return sortBy, true
+	}
+	return sortBy, false
+}
+
+// landmarkOrder returns the comparator the scored set is sorted and ranked by. Ties fall
+// back to lessLandmark so the order is deterministic. Travel duration is only known after a
+// page is cut, so that order ranks by popularity and each page is re-sorted later.
+func landmarkOrder(sortBy string) func(a, b Landmark) bool {
+	switch sortBy {
+	case SortDistance:
+		return func(a, b Landmark) bool {
+			if a.Distance != b.Distance {
+				return a.Distance < b.Distance
+			}
+			return lessLandmark(a, b)
+		}
+	case SortRating:
+		return func(a, b Landmark) bool {
+			if a.Rating != b.Rating {
+				return a.Rating > b.Rating
+			}
+			return lessLandmark(a, b)
+		}
+	case SortReviews:
+		return func(a, b Landmark) bool {
+			if a.UserRatings != b.UserRatings {
+				return a.UserRatings > b.UserRatings
+			}
+			return lessLandmark(a, b)
+		}
+	}
+	return lessLandmark
+}
+
+// ranksByScore reports whether a scored set sorted for sortBy is in popularity order
+func ranksByScore(sortBy string) bool {
+	return sortBy == "" || sortBy == SortPopularity || sortBy == SortTravelDuration
+}
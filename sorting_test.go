@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"googlemaps.github.io/maps"
+)
+
+// sortTestPlaces rank differently by every sort order
+var sortTestPlaces = []maps.PlacesSearchResult{
+	fakePlace("Bazaar", 3.8, 5000, 200),     // score 11.71
+	fakePlace("Clock Tower", 4.9, 20, 1500), // score 2.59
+	fakePlace("Library", 4.3, 600, 50),      // score 11.38
+	fakePlace("Stadium", 4.1, 2000, 3000),   // score 3.38
+}
+
+func TestSortBy(t *testing.T) {
+	tests := []struct {
+		sortBy string
+		want   []string
+	}{
+		{"", []string{"Bazaar", "Library", "Stadium", "Clock Tower"}},
+		{SortPopularity, []string{"Bazaar", "Library", "Stadium", "Clock Tower"}},
+		{SortDistance, []string{"Library", "Bazaar", "Clock Tower", "Stadium"}},
+		{SortRating, []string{"Clock Tower", "Library", "Stadium", "Bazaar"}},
+		{SortReviews, []string{"Bazaar", "Stadium", "Library", "Clock Tower"}},
+		{" Distance ", []string{"Library", "Bazaar", "Clock Tower", "Stadium"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sortBy, func(t *testing.T) {
+			got := searchNames(t, sortTestPlaces, GetLandmarksRequest{SortBy: tt.sortBy})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sort_by %q: landmarks = %v, want %v", tt.sortBy, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortByRanksWholeSet(t *testing.T) {
+	client := newAddressClient(sortTestPlaces)
+	service := newTestService(t, client)
+	req := GetLandmarksRequest{Address: "1 Mall Road", SortBy: SortRating, Limit: 2}
+
+	first, err := service.GetNearbyLandmarks(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Cursor = first.NextCursor
+	second, err := service.GetNearbyLandmarks(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := landmarkNames(append(first.Landmarks, second.Landmarks...))
+	want := []string{"Clock Tower", "Library", "Stadium", "Bazaar"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("pages = %v, want %v", got, want)
+	}
+	for i, landmark := range append(first.Landmarks, second.Landmarks...) {
+		if landmark.Rank != i+1 {
+			t.Errorf("%s: rank = %d, want %d", landmark.Name, landmark.Rank, i+1)
+		}
+	}
+}
+
+func TestSortByInvalid(t *testing.T) {
+	client := &fakeMapsClient{}
+	response, err := newTestService(t, client).GetNearbyLandmarks(context.Background(),
+		GetLandmarksRequest{Address: "1 Mall Road", SortBy: "newest"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Success || !strings.Contains(response.Message, "Invalid sort_by") {
+		t.Errorf("response = %+v, want an invalid sort_by failure", response)
+	}
+	if got := client.callCount("geocode"); got != 0 {
+		t.Errorf("geocode calls = %d, want 0", got)
+	}
+}
+
+func TestLessLandmarkTieBreak(t *testing.T) {
+	// Equal scores fall back to rating, and equal ratings to name
+	landmarks := []Landmark{
+		{Name: "Zoo", PopScore: 8, Rating: 4.0},
+		{Name: "Arch", PopScore: 8, Rating: 4.0},
+		{Name: "Well", PopScore: 8, Rating: 4.5},
+		{Name: "Mill", PopScore: 9, Rating: 3.0},
+		{Name: "Dock", PopScore: 8, Rating: 4.0},
+	}
+	want := []string{"Mill", "Well", "Arch", "Dock", "Zoo"}
+
+	// Every rotation of the input sorts to the same order
+	for i := range landmarks {
+		rotated := append(append([]Landmark{}, landmarks[i:]...), landmarks[:i]...)
+		sort.Slice(rotated, func(a, b int) bool { return lessLandmark(rotated[a], rotated[b]) })
+		if got := landmarkNames(rotated); !reflect.DeepEqual(got, want) {
+			t.Errorf("rotation %d sorted to %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestGetNearbyLandmarksTiesOrderedByName(t *testing.T) {
+	// Identical rating, reviews and distance give identical scores
+	places := []maps.PlacesSearchResult{
+		fakePlace("Temple", 4.2, 300, 400),
+		fakePlace("Fort", 4.2, 300, 400),
+		fakePlace("Museum", 4.2, 300, 400),
+	}
+	want := []string{"Fort", "Museum", "Temple"}
+	for i := range places {
+		rotated := append(append([]maps.PlacesSearchResult{}, places[i:]...), places[:i]...)
+		if got := searchNames(t, rotated, GetLandmarksRequest{}); !reflect.DeepEqual(got, want) {
+			t.Errorf("rotation %d: landmarks = %v, want %v", i, got, want)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// streamHeartbeatInterval is how often an SSE comment is sent while a search is running,
+// so proxies don't close an idle connection
+const streamHeartbeatInterval = 10 * time.Second
+
+// handleStreamLandmarks streams every scored landmark as a Server-Sent Event, followed by
+// a final "done" event. The request is checked and normalized like any landmark search;
+// an invalid one is rejected with a JSON error before the stream starts. If the client
+// disconnects, the underlying Maps calls are cancelled.
+func (s *LocationService) handleStreamLandmarks(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, ErrCodeInternal, "Streaming not supported")
+		return
+	}
+
+	query := r.URL.Query()
+	applyParamAliases(query, s.config.ParamAliases)
+	req, err := landmarksRequestFromQuery(query)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid query: "+err.Error())
+		return
+	}
+	req.Language = s.resolveLanguage(req.Language, mapsLanguages)
+	plan, failure := s.planSearch(req)
+	if failure != nil {
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidRequest, failure.Message)
+		return
+	}
+
+	// Tie the search to the client connection so a disconnect cancels it
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+	ctx = withLanguage(ctx, plan.req.Language)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	type searchResult struct {
+		set     *scoredSet
+		failure *LandmarksResponse
+		err     error
+	}
+	done := make(chan searchResult, 1)
+	go func() {
+		set, _, failure, err := s.cachedSearch(ctx, plan.req)
+		done <- searchResult{set, failure, err}
+	}()
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	var result searchResult
+	for waiting := true; waiting; {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+			flusher.Flush()
+		case result = <-done:
+			waiting = false
+		}
+	}
+
+	if result.err != nil {
+		writeSSE(w, "error", map[string]string{"message": result.err.Error()})
+		flusher.Flush()
+		return
+	}
+	if result.failure != nil {
+		writeSSE(w, "error", result.failure)
+		flusher.Flush()
+		return
+	}
+
+	for _, landmark := range result.set.Landmarks {
+		if ctx.Err() != nil {
+			return
+		}
+		writeSSE(w, "", plan.display(landmark))
+		flusher.Flush()
+	}
+
+	summary := struct {
+		Count   int    `json:"count"`
+		Message string `json:"message,omitempty"`
+	}{Count: len(result.set.Landmarks)}
+	if plan.radiusClamped {
+		summary.Message = fmt.Sprintf("radius clamped to the maximum of %.0fm", s.config.MaxSearchRadius)
+	}
+	writeSSE(w, "done", summary)
+	flusher.Flush()
+}
+
+// writeSSE writes one Server-Sent Event with a JSON payload; an empty event name
+// produces a default "message" event
+func writeSSE(w http.ResponseWriter, event string, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+	if event != "" {
+		fmt.Fprintf(w, "event: %s\n", event)
+	}
+	fmt.Fprintf(w, "data: %s\n\n", data)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"googlemaps.github.io/maps"
+)
+
+// streamLandmarks runs handleStreamLandmarks for the query against a fake finding places
+func streamLandmarks(t *testing.T, client *fakeMapsClient, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/api/landmarks/stream?"+query, nil)
+	newTestService(t, client).handleStreamLandmarks(w, r)
+	return w
+}
+
+func TestStreamLandmarksClampsRadius(t *testing.T) {
+	client := newAddressClient([]maps.PlacesSearchResult{fakePlace("Fort", 4.0, 100, 500)})
+	w := streamLandmarks(t, client, "address=1+Mall+Road&radius=60000&units=km")
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body)
+	}
+	if got := client.nearbyRequests[0].Radius; got != 50000 {
+		t.Errorf("searched %dm, want the 50000m ceiling", got)
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, `"name":"Fort"`) || !strings.Contains(body, `"distance":0.5`) {
+		t.Errorf("stream lacks the landmark in km:\n%s", body)
+	}
+	if !strings.Contains(body, "event: done\ndata: {\"count\":1,\"message\":\"radius clamped to the maximum of 50000m\"}") {
+		t.Errorf("done event doesn't note the clamp:\n%s", body)
+	}
+}
+
+func TestStreamLandmarksRejectsInvalidRequests(t *testing.T) {
+	for _, query := range []string{
+		"address=1+Mall+Road&radius=-5",
+		"address=1+Mall+Road&sort_by=newest",
+		"address=1+Mall+Road&units=furlongs",
+		"address=1+Mall+Road&radius=wide",
+	} {
+		client := newAddressClient(nil)
+		w := streamLandmarks(t, client, query)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", query, w.Code)
+			continue
+		}
+		var body ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || body.Code != ErrCodeInvalidRequest {
+			t.Errorf("%s: body = %q, want an %s JSON error", query, w.Body, ErrCodeInvalidRequest)
+		}
+		if client.callCount("geocode") != 0 {
+			t.Errorf("%s: searched despite the invalid request", query)
+		}
+	}
+}
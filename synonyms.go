@@ -0,0 +1,61 @@
+package main
+
+import (
+	"log"
+	"strings"
+)
+
+// defaultKeywordSynonyms expands common Indian shopping and service terms into the
+// variants Google indexes places under. Extend or override it with KEYWORD_SYNONYMS.
+var defaultKeywordSynonyms = map[string][]string{
+	"medicine":        {"pharmacy", "chemist"},
+	"chemist":         {"pharmacy", "medical store"},
+	"pharmacy":        {"chemist", "medical store"},
+	"medical store":   {"pharmacy", "chemist"},
+	"provision store": {"grocery", "kirana"},
+	"kirana":          {"grocery", "provision store"},
+	"grocery":         {"kirana", "supermarket"},
+	"petrol pump":     {"gas station", "fuel station"},
+	"dhaba":           {"restaurant"},
+}
+
+// parseKeywordSynonyms merges "term:syn1|syn2" entries from a comma-separated list over the defaults
+func parseKeywordSynonyms(value string) map[string][]string {
+	synonyms := make(map[string][]string, len(defaultKeywordSynonyms))
+	for term, list := range defaultKeywordSynonyms {
+		synonyms[term] = list
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		term, list, ok := strings.Cut(entry, ":")
+		term = strings.ToLower(strings.TrimSpace(term))
+		if !ok || term == "" {
+			log.Printf("Ignoring invalid KEYWORD_SYNONYMS entry %q", entry)
+			continue
+		}
+		var expanded []string
+		for _, syn := range strings.Split(list, "|") {
+			if syn = strings.ToLower(strings.TrimSpace(syn)); syn != "" {
+				expanded = append(expanded, syn)
+			}
+		}
+		synonyms[term] = expanded
+	}
+	return synonyms
+}
+
+// expandKeyword returns the keyword followed by its synonyms, capped at
+// Config.KeywordExpansionLimit searches in total
+func (s *LocationService) expandKeyword(keyword string) []string {
+	terms := []string{keyword}
+	for _, syn := range s.config.KeywordSynonyms[strings.ToLower(keyword)] {
+		if len(terms) >= s.config.KeywordExpansionLimit {
+			break
+		}
+		terms = append(terms, syn)
+	}
+	return terms
+}
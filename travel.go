@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+
+	"googlemaps.github.io/maps"
+)
+
+// travelModes maps GetLandmarksRequest.TravelMode values to Distance Matrix modes
+var travelModes = map[string]maps.Mode{
+	"driving":   maps.TravelModeDriving,
+	"walking":   maps.TravelModeWalking,
+	"bicycling": maps.TravelModeBicycling,
+	"transit":   maps.TravelModeTransit,
+}
+
+// parseTravelMode normalizes a requested travel mode. An empty mode means no travel
+// enrichment and returns "", true.
+func parseTravelMode(mode string) (maps.Mode, bool) {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if mode == "" {
+		return "", true
+	}
+	travelMode, ok := travelModes[mode]
+	return travelMode, ok
+}
+
+// enrichWithTravel sets each landmark's TravelDistance and TravelDuration to the route from
+// origin by the given mode, next to the straight-line Distance which scoring keeps using.
+// All landmarks go into one Distance Matrix call (more only beyond maxMatrixDestinations),
+// billed one element per landmark. The routes are returned in landmark order so walkability
+// can reuse walking routes; nil if the lookup failed. A landmark Google found no route to
+// keeps its travel fields unset.
+func (s *LocationService) enrichWithTravel(ctx context.Context, origin maps.LatLng, landmarks []Landmark, mode maps.Mode) []maps.DistanceMatrixElement {
+	if len(landmarks) == 0 {
+		return nil
+	}
+
+	points := make([]maps.LatLng, len(landmarks))
+	for i, landmark := range landmarks {
+		points[i] = maps.LatLng{Lat: landmark.Location.Lat, Lng: landmark.Location.Lng}
+	}
+	elements, err := s.travelMatrix(ctx, origin, points, mode)
+	if err != nil {
+		log.Printf("Travel lookup (%s) failed: %v", mode, err)
+		return nil
+	}
+
+	for i := range landmarks {
+		if element := elements[i]; element.Status == "OK" {
+			distance := float64(element.Distance.Meters)
+			duration := element.Duration.Seconds()
+			landmarks[i].TravelDistance = &distance
+			landmarks[i].TravelDuration = &duration
+		}
+	}
+	return elements
+}
+
+// sortByTravelDuration orders landmarks by travel time, shortest first. Landmarks without
+// a travel time keep their relative order after those with one.
+func sortByTravelDuration(landmarks []Landmark) {
+	sort.SliceStable(landmarks, func(i, j int) bool {
+		a, b := landmarks[i].TravelDuration, landmarks[j].TravelDuration
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return *a < *b
+	})
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"sort"
+	"strings"
+
+	"googlemaps.github.io/maps"
+)
+
+// maxTypes caps how many place types one request may filter by, since each costs a search
+const maxTypes = 5
+
+// validPlaceTypes normalizes the requested place types and splits them into those Google
+// supports and those it doesn't, dropping duplicates
+func validPlaceTypes(types []string) (valid, ignored []string) {
+	seen := make(map[string]bool, len(types))
+	for _, t := range types {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		if _, err := maps.ParsePlaceType(t); err == nil || genericPlaceTypes[t] {
+			valid = append(valid, t)
+		} else {
+			ignored = append(ignored, t)
+		}
+	}
+	return valid, ignored
+}
+
+// hasAnyType reports whether a place's types include any of the wanted ones
+func hasAnyType(placeTypes, wanted []string) bool {
+	for _, t := range placeTypes {
+		for _, w := range wanted {
+			if t == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AvailableType is a place type found among a search's results, for building filter chips
+type AvailableType struct {
+	Type  string `json:"type"`
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// availableTypes counts the distinct place types across landmarks, most frequent first
+// and alphabetically on ties
+func availableTypes(landmarks []Landmark) []AvailableType {
+	counts := make(map[string]int)
+	for _, landmark := range landmarks {
+		for _, t := range landmark.Types {
+			counts[t]++
+		}
+	}
+
+	available := make([]AvailableType, 0, len(counts))
+	for t, count := range counts {
+		available = append(available, AvailableType{Type: t, Label: placeTypeLabel(t), Count: count})
+	}
+	sort.Slice(available, func(i, j int) bool {
+		if available[i].Count != available[j].Count {
+			return available[i].Count > available[j].Count
+		}
+		return available[i].Type < available[j].Type
+	})
+	return available
+}
+
+// placeTypeLabel turns a Google place type into a display label, e.g.
+// "tourist_attraction" into "Tourist attraction"
+func placeTypeLabel(placeType string) string {
+	label := strings.ReplaceAll(placeType, "_", " ")
+	if label == "" {
+		return label
+	}
+	return strings.ToUpper(label[:1]) + label[1:]
+}
+
+// trimTypes shortens a landmark's types for smaller payloads. specificOnly drops generic
+// types such as "establishment"; limit > 0 then keeps only the first limit, which are the
+// most specific since Google lists types in that order. The input slice is not modified.
+func trimTypes(types []string, limit int, specificOnly bool) []string {
+	trimmed := types
+	if specificOnly {
+		trimmed = make([]string, 0, len(types))
+		for _, t := range types {
+			if !genericPlaceTypes[t] {
+				trimmed = append(trimmed, t)
+			}
+		}
+	}
+	if limit > 0 && len(trimmed) > limit {
+		trimmed = trimmed[:limit:limit]
+	}
+	return trimmed
+}
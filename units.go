@@ -0,0 +1,38 @@
+package main
+
+import "strings"
+
+// Distance units accepted in requests and reported in responses
+const (
+	UnitMeters     = "m"
+	UnitKilometers = "km"
+	UnitMiles      = "mi"
+)
+
+// metersPerUnit converts each supported unit to meters
+var metersPerUnit = map[string]float64{
+	UnitMeters:     1,
+	UnitKilometers: 1000,
+	UnitMiles:      1609.344,
+}
+
+// parseUnit normalizes a requested distance unit, defaulting to meters. ok is false for
+// unsupported units.
+func parseUnit(unit string) (normalized string, ok bool) {
+	unit = strings.ToLower(strings.TrimSpace(unit))
+	if unit == "" {
+		return UnitMeters, true
+	}
+	_, ok = metersPerUnit[unit]
+	return unit, ok
+}
+
+// convertDistance converts meters to the given unit for display, rounded to one decimal.
+// Internal calculations (scoring, filtering) always stay in meters.
+func convertDistance(meters float64, unit string) float64 {
+	factor, ok := metersPerUnit[unit]
+	if !ok {
+		factor = 1
+	}
+	return roundTo(meters/factor, 1)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"log"
+	"math"
+
+	"googlemaps.github.io/maps"
+)
+
+// Walkability tuning: places beyond maxWalkDistance meters (straight line) or
+// maxWalkMinutes on foot score 0
+const (
+	maxWalkDistance = 2000
+	maxWalkMinutes  = 30
+)
+
+// enrichWithWalkability sets each landmark's Walkability, from 0 (not walkable) to 1.
+// With withWalkingTime it also uses walking routes from origin and sets WalkingDuration. The
+// routes are walkingRoutes when already fetched, in landmark order, and are otherwise fetched
+// with the Distance Matrix API; if that call fails, walkability falls back to distance only.
+func (s *LocationService) enrichWithWalkability(ctx context.Context, origin maps.LatLng, landmarks []Landmark, withWalkingTime bool, walkingRoutes []maps.DistanceMatrixElement) {
+	if len(landmarks) == 0 {
+		return
+	}
+
+	var elements []maps.DistanceMatrixElement
+	if withWalkingTime && walkingRoutes != nil {
+		elements = walkingRoutes
+	} else if withWalkingTime {
+		points := make([]maps.LatLng, len(landmarks))
+		for i, landmark := range landmarks {
+			points[i] = maps.LatLng{Lat: landmark.Location.Lat, Lng: landmark.Location.Lng}
+		}
+		var err error
+		elements, err = s.travelMatrix(ctx, origin, points, maps.TravelModeWalking)
+		if err != nil {
+			log.Printf("Walking time lookup failed, using distance-only walkability: %v", err)
+			elements = nil
+		}
+	}
+
+	for i := range landmarks {
+		landmark := &landmarks[i]
+		var walkability float64
+		if elements == nil {
+			walkability = distanceWalkability(landmark.Distance)
+		} else if element := elements[i]; element.Status == "OK" {
+			duration := element.Duration.Seconds()
+			landmark.WalkingDuration = &duration
+			walkability = routeWalkability(landmark.Distance, element)
+		}
+		walkability = roundTo(walkability, 2)
+		landmark.Walkability = &walkability
+	}
+}
+
+// distanceWalkability falls off linearly with straight-line distance
+func distanceWalkability(distance float64) float64 {
+	return math.Max(0, 1-distance/maxWalkDistance)
+}
+
+// routeWalkability weighs walking time (70%) against how direct the walking route is
+// compared to the straight line (30%)
+func routeWalkability(distance float64, route maps.DistanceMatrixElement) float64 {
+	timeScore := math.Max(0, 1-route.Duration.Minutes()/maxWalkMinutes)
+	directness := 1.0
+	if route.Distance.Meters > 0 {
+		directness = math.Min(1, distance/float64(route.Distance.Meters))
+	}
+	return 0.7*timeScore + 0.3*directness
+}
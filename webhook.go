@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// maxWebhookResponseBytes caps how much of the webhook reply we read
+const maxWebhookResponseBytes = 1 << 20
+
+// landmarkJSONFields is the set of JSON keys Landmark already serializes.
+// Keys returned by the webhook outside this set are treated as enrichment.
+var landmarkJSONFields = jsonFieldNames(reflect.TypeOf(Landmark{}))
+
+// transformLandmarks posts the scored landmarks to the configured result webhook
+// and merges back any fields it adds, matched by place_id.
+// Any failure falls back to the untransformed landmarks.
+func (s *LocationService) transformLandmarks(ctx context.Context, landmarks []Landmark) []Landmark {
+	if s.config.ResultWebhookURL == "" || len(landmarks) == 0 {
+		return landmarks
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.ResultWebhookTimeout)
+	defer cancel()
+
+	transformed, err := s.callResultWebhook(ctx, landmarks)
+	if err != nil {
+		log.Printf("Result webhook failed, using untransformed results: %v", err)
+		return landmarks
+	}
+
+	byPlaceID := make(map[string]map[string]json.RawMessage, len(transformed))
+	for _, item := range transformed {
+		var placeID string
+		if raw, ok := item["place_id"]; ok {
+			json.Unmarshal(raw, &placeID)
+		}
+		if placeID != "" {
+			byPlaceID[placeID] = item
+		}
+	}
+
+	merged := make([]Landmark, len(landmarks))
+	for i, landmark := range landmarks {
+		merged[i] = landmark
+		item, ok := byPlaceID[landmark.PlaceID]
+		if !ok {
+			continue
+		}
+		for key, value := range item {
+			if key == "busyness" {
+				var busyness float64
+				if json.Unmarshal(value, &busyness) == nil {
+					merged[i].Busyness = &busyness
+				}
+				continue
+			}
+			if landmarkJSONFields[key] {
+				continue
+			}
+			if merged[i].Enrichment == nil {
+				merged[i].Enrichment = make(map[string]json.RawMessage)
+			}
+			merged[i].Enrichment[key] = value
+		}
+	}
+
+	return merged
+}
+
+// callResultWebhook sends the landmarks as a JSON array and decodes the returned array
+func (s *LocationService) callResultWebhook(ctx context.Context, landmarks []Landmark) ([]map[string]json.RawMessage, error) {
+	body, err := json.Marshal(landmarks)
+	if err != nil {
+		return nil, fmt.Errorf("encoding landmarks: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.ResultWebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("building request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var transformed []map[string]json.RawMessage
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxWebhookResponseBytes)).Decode(&transformed); err != nil {
+		return nil, fmt.Errorf("decoding response: %v", err)
+	}
+	return transformed, nil
+}
+
+// jsonFieldNames returns the JSON keys used by the exported fields of a struct type
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names[name] = true
+	}
+	return names
+}